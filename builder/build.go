@@ -14,15 +14,25 @@
 
 // Package builder provides a way to construct a ga4gh.Evaluator from a
 // protocol buffer description of it.  This is useful for applications which
-// have a stored configuration.
+// have a stored configuration.  Implementations of ga4gh.Shim, ga4gh.Validator
+// and ga4gh.DenyList from other packages can be made available to stored
+// configurations with RegisterShim, RegisterValidator and RegisterDenyList.
 package builder
 
 import (
 	"context"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
 
 	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/denylist"
+	"github.com/googlegenomics/ga4gh-identity/groups"
 	"github.com/googlegenomics/ga4gh-identity/shim/elixir"
+	"github.com/googlegenomics/ga4gh-identity/shim/introspection"
+	"github.com/googlegenomics/ga4gh-identity/userinfo"
 	"github.com/googlegenomics/ga4gh-identity/validator"
 )
 
@@ -39,13 +49,57 @@ func Build(ctx context.Context, e *Evaluator) (*ga4gh.Evaluator, error) {
 		return nil, fmt.Errorf("building validator: %v", err)
 	}
 
-	return &ga4gh.Evaluator{
+	ev := &ga4gh.Evaluator{
 		Parser:    parser,
 		Validator: validator,
-	}, nil
+	}
+	if len(e.Datasets) > 0 {
+		if ev.Authorizer, err = buildDatasets(ctx, e.Datasets); err != nil {
+			return nil, fmt.Errorf("building datasets: %v", err)
+		}
+	}
+	for i, en := range e.Enrichers {
+		built, err := buildEnricher(ctx, en)
+		if err != nil {
+			return nil, fmt.Errorf("building enricher %d: %v", i, err)
+		}
+		ev.Enrichers = append(ev.Enrichers, built)
+	}
+	if e.Cache != nil {
+		if ev.Cache, err = buildCache(e.Cache); err != nil {
+			return nil, fmt.Errorf("building cache: %v", err)
+		}
+	}
+	return ev, nil
+}
+
+func buildCache(c *Evaluator_Cache) (*ga4gh.DecisionCache, error) {
+	var ttl time.Duration
+	if c.Ttl != "" {
+		var err error
+		if ttl, err = time.ParseDuration(c.Ttl); err != nil {
+			return nil, fmt.Errorf("parsing ttl: %v", err)
+		}
+	}
+	return ga4gh.NewDecisionCache(int(c.MaxEntries), ttl), nil
+}
+
+func buildDatasets(ctx context.Context, ds map[string]*Validator) (validator.Datasets, error) {
+	built := make(validator.Datasets)
+	for id, v := range ds {
+		gv, err := buildValidator(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("dataset %q: %v", id, err)
+		}
+		built[id] = gv
+	}
+	return built, nil
 }
 
 func buildParser(ctx context.Context, p *Parser) (*ga4gh.Parser, error) {
+	if p == nil {
+		return nil, fmt.Errorf("no parser configured")
+	}
 	var shims []ga4gh.Shim
 	for _, shim := range p.Shims {
 		gs, err := buildShim(ctx, shim)
@@ -54,7 +108,79 @@ func buildParser(ctx context.Context, p *Parser) (*ga4gh.Parser, error) {
 		}
 		shims = append(shims, gs)
 	}
-	return ga4gh.NewParser(ctx, shims, p.Issuers)
+	issuers, err := issuerOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := ga4gh.NewParserWithOptions(ctx, shims, issuers)
+	if err != nil {
+		return nil, err
+	}
+	if p.DenyList != nil {
+		if parser.DenyList, err = buildDenyList(ctx, p.DenyList); err != nil {
+			return nil, fmt.Errorf("building deny list: %v", err)
+		}
+	}
+	return parser, nil
+}
+
+// issuerOptions combines the issuers and issuer_options of p.
+func issuerOptions(p *Parser) (map[string]ga4gh.IssuerOptions, error) {
+	options := make(map[string]ga4gh.IssuerOptions)
+	for issuer, clientID := range p.Issuers {
+		options[issuer] = ga4gh.IssuerOptions{Audiences: []string{clientID}}
+	}
+	for issuer, o := range p.IssuerOptions {
+		if _, ok := options[issuer]; ok {
+			return nil, fmt.Errorf("issuer %q is listed in both issuers and issuer_options", issuer)
+		}
+		built, err := buildIssuerOptions(o)
+		if err != nil {
+			return nil, fmt.Errorf("issuer %q: %v", issuer, err)
+		}
+		options[issuer] = built
+	}
+	return options, nil
+}
+
+func buildIssuerOptions(o *IssuerOptions) (ga4gh.IssuerOptions, error) {
+	built := ga4gh.IssuerOptions{
+		Audiences:      o.GetAudiences(),
+		Algorithms:     o.GetAlgorithms(),
+		RequiredClaims: o.GetRequiredClaims(),
+	}
+	var err error
+	if o.GetClockSkew() != "" {
+		if built.ClockSkew, err = time.ParseDuration(o.ClockSkew); err != nil {
+			return ga4gh.IssuerOptions{}, fmt.Errorf("parsing clock skew: %v", err)
+		}
+	}
+	if o.GetMaxAge() != "" {
+		if built.MaxAge, err = time.ParseDuration(o.MaxAge); err != nil {
+			return ga4gh.IssuerOptions{}, fmt.Errorf("parsing max age: %v", err)
+		}
+	}
+	return built, nil
+}
+
+func buildDenyList(ctx context.Context, d *DenyList) (ga4gh.DenyList, error) {
+	switch d := d.DenyList.(type) {
+	case *DenyList_File_:
+		if d.File.Path == "" {
+			return nil, fmt.Errorf("no deny list file path")
+		}
+		interval, err := reloadInterval(d.File.ReloadInterval)
+		if err != nil {
+			return nil, err
+		}
+		return denylist.Open(d.File.Path, interval)
+
+	case *DenyList_Plugin:
+		return buildPluginDenyList(ctx, d.Plugin)
+
+	default:
+		return nil, fmt.Errorf("unsupported %T deny list", d)
+	}
 }
 
 func buildShim(ctx context.Context, s *Shim) (ga4gh.Shim, error) {
@@ -66,11 +192,52 @@ func buildShim(ctx context.Context, s *Shim) (ga4gh.Shim, error) {
 		}
 		return gs, nil
 
+	case *Shim_Introspection_:
+		config, err := introspectionConfig(s.Introspection)
+		if err != nil {
+			return nil, fmt.Errorf("building introspection shim: %v", err)
+		}
+		gs, err := introspection.NewShim(config)
+		if err != nil {
+			return nil, fmt.Errorf("building introspection shim: %v", err)
+		}
+		return gs, nil
+
+	case *Shim_Plugin:
+		gs, err := buildPluginShim(ctx, s.Plugin)
+		if err != nil {
+			return nil, fmt.Errorf("building plugin shim: %v", err)
+		}
+		return gs, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported %T shim", s)
 	}
 }
 
+func introspectionConfig(i *Shim_Introspection) (introspection.Config, error) {
+	config := introspection.Config{
+		Endpoint:     i.Endpoint,
+		Issuer:       i.Issuer,
+		ClientID:     i.ClientId,
+		ClientSecret: i.ClientSecret,
+		CacheEntries: int(i.CacheEntries),
+	}
+	if i.Timeout != "" {
+		var err error
+		if config.Timeout, err = time.ParseDuration(i.Timeout); err != nil {
+			return introspection.Config{}, fmt.Errorf("parsing timeout: %v", err)
+		}
+	}
+	return config, nil
+}
+
+// BuildValidator constructs a ga4gh.Validator from the protocol buffer
+// definition of a Validator.  A nil Validator never validates.
+func BuildValidator(ctx context.Context, v *Validator) (ga4gh.Validator, error) {
+	return buildValidator(ctx, v)
+}
+
 func buildValidator(ctx context.Context, v *Validator) (ga4gh.Validator, error) {
 	if v == nil {
 		return &validator.Constant{OK: false}, nil
@@ -82,6 +249,9 @@ func buildValidator(ctx context.Context, v *Validator) (ga4gh.Validator, error)
 		if err != nil {
 			return nil, fmt.Errorf("building 'And' validator: %v", err)
 		}
+		if v.And.Parallel {
+			return validator.ParallelAnd(vs), nil
+		}
 		return validator.And(vs), nil
 
 	case *Validator_Or_:
@@ -89,18 +259,91 @@ func buildValidator(ctx context.Context, v *Validator) (ga4gh.Validator, error)
 		if err != nil {
 			return nil, fmt.Errorf("building 'Or' validator: %v", err)
 		}
+		if v.Or.Parallel {
+			return validator.ParallelOr(vs), nil
+		}
 		return validator.Or(vs), nil
 
+	case *Validator_Not_:
+		gv, err := buildValidator(ctx, v.Not.Validator)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Not' validator: %v", err)
+		}
+		return &validator.Not{Validator: gv}, nil
+
+	case *Validator_AtLeast_:
+		vs, err := buildValidators(ctx, v.AtLeast.Validators)
+		if err != nil {
+			return nil, fmt.Errorf("building 'AtLeast' validator: %v", err)
+		}
+		return &validator.AtLeast{N: int(v.AtLeast.N), Validators: vs}, nil
+
+	case *Validator_Exactly_:
+		vs, err := buildValidators(ctx, v.Exactly.Validators)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Exactly' validator: %v", err)
+		}
+		return &validator.Exactly{N: int(v.Exactly.N), Validators: vs}, nil
+
 	case *Validator_Simple_:
-		gv := make(validator.Simple)
-		for claim, value := range v.Simple.Claims {
-			gv[claim] = value
+		gv, err := buildSimple(v.Simple)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Simple' validator: %v", err)
 		}
 		return gv, nil
 
 	case *Validator_Constant_:
 		return &validator.Constant{OK: v.Constant.Value}, nil
 
+	case *Validator_Sourced_:
+		gv, err := buildSourced(v.Sourced)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Sourced' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Timed_:
+		gv, err := buildTimed(v.Timed)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Timed' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Visa_:
+		gv, err := buildVisa(v.Visa)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Visa' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Groups_:
+		gv, err := buildGroups(v.Groups)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Groups' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Match_:
+		gv, err := buildMatch(v.Match)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Match' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Expression_:
+		gv, err := buildExpression(v.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("building 'Expression' validator: %v", err)
+		}
+		return gv, nil
+
+	case *Validator_Plugin:
+		gv, err := buildPluginValidator(ctx, v.Plugin)
+		if err != nil {
+			return nil, fmt.Errorf("building plugin validator: %v", err)
+		}
+		return gv, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported %T validator", v)
 	}
@@ -117,3 +360,362 @@ func buildValidators(ctx context.Context, vs []*Validator) ([]ga4gh.Validator, e
 	}
 	return built, nil
 }
+
+func buildSimple(s *Validator_Simple) (validator.Simple, error) {
+	gv := make(validator.Simple)
+	for claim, value := range s.Claims {
+		typ, ok := validator.FieldType(claim)
+		if !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", claim)
+		}
+		converted, err := convertString(value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("claim %q: %v", claim, err)
+		}
+		gv[claim] = converted
+	}
+	for claim, value := range s.TypedClaims {
+		if _, ok := s.Claims[claim]; ok {
+			return nil, fmt.Errorf("claim %q is set in both claims and typed_claims", claim)
+		}
+		typ, ok := validator.FieldType(claim)
+		if !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", claim)
+		}
+		converted, err := convertValue(value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("claim %q: %v", claim, err)
+		}
+		gv[claim] = converted
+	}
+	return gv, nil
+}
+
+func buildSourced(s *Validator_Sourced) (validator.Sourced, error) {
+	gv := make(validator.Sourced)
+	for claim, c := range s.Claims {
+		typ, ok := validator.FieldType(claim)
+		if !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", claim)
+		}
+		if !validator.HasSource(claim) {
+			return nil, fmt.Errorf("claim %q has no source", claim)
+		}
+		if len(c.Sources) == 0 {
+			return nil, fmt.Errorf("claim %q has no trusted sources", claim)
+		}
+		value, err := convertValue(c.Value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("claim %q: %v", claim, err)
+		}
+		gv[claim] = validator.SourcedClaim{Value: value, Sources: c.Sources}
+	}
+	return gv, nil
+}
+
+func buildTimed(t *Validator_Timed) (validator.Timed, error) {
+	gv := make(validator.Timed)
+	for claim, c := range t.Claims {
+		typ, ok := validator.FieldType(claim)
+		if !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", claim)
+		}
+		if !validator.HasTimestamps(claim) {
+			return nil, fmt.Errorf("claim %q has no timestamps", claim)
+		}
+		var tc validator.TimedClaim
+		if c.Value != nil {
+			value, err := convertValue(c.Value, typ)
+			if err != nil {
+				return nil, fmt.Errorf("claim %q: %v", claim, err)
+			}
+			tc.Value = value
+		}
+		var err error
+		if c.MaxAge != "" {
+			if tc.MaxAge, err = time.ParseDuration(c.MaxAge); err != nil {
+				return nil, fmt.Errorf("claim %q: parsing max age: %v", claim, err)
+			}
+		}
+		if c.MinRemaining != "" {
+			if tc.MinRemaining, err = time.ParseDuration(c.MinRemaining); err != nil {
+				return nil, fmt.Errorf("claim %q: parsing min remaining: %v", claim, err)
+			}
+		}
+		gv[claim] = tc
+	}
+	return gv, nil
+}
+
+func buildVisa(v *Validator_Visa) (*validator.Visa, error) {
+	if v.Type == "" {
+		return nil, fmt.Errorf("no visa type")
+	}
+	for _, field := range []string{v.Value, v.Source, v.By} {
+		if err := ga4gh.CheckConditionField(field); err != nil {
+			return nil, err
+		}
+	}
+	built := &validator.Visa{Type: v.Type, Value: v.Value, Source: v.Source, By: v.By}
+	var err error
+	if v.MaxAge != "" {
+		if built.MaxAge, err = time.ParseDuration(v.MaxAge); err != nil {
+			return nil, fmt.Errorf("parsing max age: %v", err)
+		}
+	}
+	if v.MinRemaining != "" {
+		if built.MinRemaining, err = time.ParseDuration(v.MinRemaining); err != nil {
+			return nil, fmt.Errorf("parsing min remaining: %v", err)
+		}
+	}
+	return built, nil
+}
+
+func buildGroups(g *Validator_Groups) (*groups.Validator, error) {
+	if len(g.Groups) == 0 {
+		return nil, fmt.Errorf("no groups")
+	}
+	f, err := openGroupFile(g.File)
+	if err != nil {
+		return nil, err
+	}
+	return &groups.Validator{File: f, Groups: g.Groups}, nil
+}
+
+func buildEnricher(ctx context.Context, e *Enricher) (ga4gh.Enricher, error) {
+	switch e := e.GetEnricher().(type) {
+	case *Enricher_Groups_:
+		f, err := openGroupFile(e.Groups.File)
+		if err != nil {
+			return nil, err
+		}
+		source := e.Groups.Source
+		if source == "" {
+			source = f.Path()
+		}
+		return &groups.Enricher{File: f, Source: source}, nil
+
+	case *Enricher_UserInfo_:
+		config, err := userInfoConfig(e.UserInfo)
+		if err != nil {
+			return nil, err
+		}
+		if e.UserInfo.Format == Enricher_UserInfo_ELIXIR {
+			d, err := elixir.NewDecoder(ctx, e.UserInfo.Issuer)
+			if err != nil {
+				return nil, fmt.Errorf("creating ELIXIR decoder: %v", err)
+			}
+			config.Decode = d.Decode
+		}
+		return userinfo.NewEnricher(ctx, config)
+	}
+	return nil, fmt.Errorf("unsupported %T enricher", e.GetEnricher())
+}
+
+func userInfoConfig(u *Enricher_UserInfo) (userinfo.Config, error) {
+	config := userinfo.Config{
+		Issuer:       u.Issuer,
+		Endpoint:     u.Endpoint,
+		CacheEntries: int(u.CacheEntries),
+	}
+	if _, ok := Enricher_UserInfo_Format_name[int32(u.Format)]; !ok {
+		return userinfo.Config{}, fmt.Errorf("unsupported format %v", u.Format)
+	}
+	var err error
+	if u.Timeout != "" {
+		if config.Timeout, err = time.ParseDuration(u.Timeout); err != nil {
+			return userinfo.Config{}, fmt.Errorf("parsing timeout: %v", err)
+		}
+	}
+	if u.CacheTtl != "" {
+		if config.CacheTTL, err = time.ParseDuration(u.CacheTtl); err != nil {
+			return userinfo.Config{}, fmt.Errorf("parsing cache ttl: %v", err)
+		}
+	}
+	return config, nil
+}
+
+// reloadInterval parses the reload interval of a file, which may be unset.
+func reloadInterval(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("parsing reload interval: %v", err)
+	}
+	return d, nil
+}
+
+func openGroupFile(f *GroupFile) (*groups.File, error) {
+	if f.GetPath() == "" {
+		return nil, fmt.Errorf("no group file path")
+	}
+	interval, err := reloadInterval(f.ReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+	return groups.Open(f.Path, interval)
+}
+
+func buildMatch(m *Validator_Match) (validator.Match, error) {
+	gv := make(validator.Match)
+	for claim, matcher := range m.Claims {
+		typ, ok := validator.FieldType(claim)
+		if !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", claim)
+		}
+		if typ.Kind() != reflect.String {
+			return nil, fmt.Errorf("claim %q is a %v, not a string", claim, typ)
+		}
+		built, err := buildMatcher(matcher)
+		if err != nil {
+			return nil, fmt.Errorf("claim %q: %v", claim, err)
+		}
+		gv[claim] = built
+	}
+	return gv, nil
+}
+
+func buildMatcher(m *Matcher) (validator.Matcher, error) {
+	switch m := m.GetMatcher().(type) {
+	case *Matcher_Regexp:
+		re, err := validator.NewRegexp(m.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compiling regexp: %v", err)
+		}
+		return re, nil
+	case *Matcher_Glob:
+		return validator.NewGlob(m.Glob), nil
+	case *Matcher_Prefix:
+		return validator.Prefix(m.Prefix), nil
+	case *Matcher_Set_:
+		return validator.NewSet(m.Set.Values...), nil
+	}
+	return nil, fmt.Errorf("unsupported %T matcher", m.GetMatcher())
+}
+
+func buildExpression(e *Validator_Expression) (*validator.Expression, error) {
+	vars := make(map[string]interface{})
+	for name, value := range e.Variables {
+		v, err := expressionValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %v", name, err)
+		}
+		vars[name] = v
+	}
+	return validator.CompileExpression(e.Source, vars)
+}
+
+// expressionValue converts v to a value usable as an expression variable,
+// converting an any_of value into a slice.
+func expressionValue(v *Value) (interface{}, error) {
+	switch v := v.GetValue().(type) {
+	case *Value_StringValue:
+		return v.StringValue, nil
+	case *Value_BoolValue:
+		return v.BoolValue, nil
+	case *Value_NumberValue:
+		return v.NumberValue, nil
+	case *Value_AnyOf_:
+		if len(v.AnyOf.Values) == 0 {
+			return nil, fmt.Errorf("empty any_of value")
+		}
+		var list reflect.Value
+		for i, value := range v.AnyOf.Values {
+			elem, err := expressionValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("any_of value %d: %v", i, err)
+			}
+			typ := reflect.TypeOf(elem)
+			switch {
+			case typ.Kind() == reflect.Slice:
+				return nil, fmt.Errorf("any_of value %d: lists cannot be nested", i)
+			case i == 0:
+				list = reflect.MakeSlice(reflect.SliceOf(typ), 0, len(v.AnyOf.Values))
+			case typ != list.Type().Elem():
+				return nil, fmt.Errorf("any_of value %d: %v in list of %v", i, typ, list.Type().Elem())
+			}
+			list = reflect.Append(list, reflect.ValueOf(elem))
+		}
+		return list.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported %T value", v.GetValue())
+}
+
+// convertString parses s as a value of type typ.
+func convertString(s string, typ reflect.Type) (interface{}, error) {
+	switch typ.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(s).Convert(typ).Interface(), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %v", s, typ)
+		}
+		return reflect.ValueOf(b).Convert(typ).Interface(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %v", s, typ)
+		}
+		return convertNumber(f, typ)
+	}
+	return nil, fmt.Errorf("unsupported field type %v", typ)
+}
+
+// convertValue converts v to a value of type typ, or to a validator.AnyOf of
+// such values.
+func convertValue(v *Value, typ reflect.Type) (interface{}, error) {
+	switch v := v.GetValue().(type) {
+	case *Value_StringValue:
+		if typ.Kind() != reflect.String {
+			return nil, fmt.Errorf("string value %q cannot be compared with %v", v.StringValue, typ)
+		}
+		return reflect.ValueOf(v.StringValue).Convert(typ).Interface(), nil
+	case *Value_BoolValue:
+		if typ.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("bool value %v cannot be compared with %v", v.BoolValue, typ)
+		}
+		return reflect.ValueOf(v.BoolValue).Convert(typ).Interface(), nil
+	case *Value_NumberValue:
+		return convertNumber(v.NumberValue, typ)
+	case *Value_AnyOf_:
+		var any validator.AnyOf
+		for i, value := range v.AnyOf.Values {
+			converted, err := convertValue(value, typ)
+			if err != nil {
+				return nil, fmt.Errorf("any_of value %d: %v", i, err)
+			}
+			any = append(any, converted)
+		}
+		return any, nil
+	}
+	return nil, fmt.Errorf("unsupported %T value", v.GetValue())
+}
+
+// convertNumber converts f to the numeric type typ, failing if typ is not
+// numeric or cannot represent f exactly.
+func convertNumber(f float64, typ reflect.Type) (interface{}, error) {
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(f).Convert(typ).Interface(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := reflect.New(typ).Elem()
+		if f != math.Trunc(f) || v.OverflowInt(int64(f)) {
+			return nil, fmt.Errorf("number %v cannot be represented as %v", f, typ)
+		}
+		v.SetInt(int64(f))
+		return v.Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v := reflect.New(typ).Elem()
+		if f != math.Trunc(f) || f < 0 || v.OverflowUint(uint64(f)) {
+			return nil, fmt.Errorf("number %v cannot be represented as %v", f, typ)
+		}
+		v.SetUint(uint64(f))
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("number %v cannot be compared with %v", f, typ)
+}
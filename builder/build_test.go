@@ -16,9 +16,16 @@ package builder
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/validator"
 )
 
 var id = &ga4gh.Identity{
@@ -30,6 +37,12 @@ var id = &ga4gh.Identity{
 		{Value: "human"},
 		{Value: "person"},
 	},
+	BonaFide: []ga4gh.BoolValue{
+		{Value: true, Source: "https://login.elixir-czech.org/oidc/"},
+	},
+	Visas: []ga4gh.Visa{
+		{Type: "ControlledAccessGrants", Value: "phs000710", Source: "https://dbgap.example.org"},
+	},
 }
 
 func TestBuildValidator(t *testing.T) {
@@ -52,6 +65,181 @@ func TestBuildValidator(t *testing.T) {
 			},
 			ok: true,
 		},
+		{
+			name: "string claim converted to bool",
+			in: &Validator{
+				Validator: &Validator_Simple_{
+					Simple: &Validator_Simple{
+						Claims: map[string]string{"BonaFide": "true"},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "typed claims",
+			in: &Validator{
+				Validator: &Validator_Simple_{
+					Simple: &Validator_Simple{
+						TypedClaims: map[string]*Value{
+							"BonaFide": {Value: &Value_BoolValue{BoolValue: true}},
+							"Role": {Value: &Value_AnyOf_{AnyOf: &Value_AnyOf{
+								Values: []*Value{
+									{Value: &Value_StringValue{StringValue: "robot"}},
+									{Value: &Value_StringValue{StringValue: "person"}},
+								},
+							}}},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "typed claim mismatch",
+			in: &Validator{
+				Validator: &Validator_Simple_{
+					Simple: &Validator_Simple{
+						TypedClaims: map[string]*Value{
+							"BonaFide": {Value: &Value_BoolValue{BoolValue: false}},
+						},
+					},
+				},
+			},
+			ok: false,
+		},
+		{
+			name: "sourced",
+			in: &Validator{
+				Validator: &Validator_Sourced_{
+					Sourced: &Validator_Sourced{
+						Claims: map[string]*Validator_Sourced_Claim{
+							"BonaFide": {
+								Value:   &Value{Value: &Value_BoolValue{BoolValue: true}},
+								Sources: []string{"https://login.elixir-czech.org/oidc/"},
+							},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "match",
+			in: &Validator{
+				Validator: &Validator_Match_{
+					Match: &Validator_Match{
+						Claims: map[string]*Matcher{
+							"OriginOrganization": {Matcher: &Matcher_Regexp{Regexp: "M.*s"}},
+							"Role":               {Matcher: &Matcher_Set_{Set: &Matcher_Set{Values: []string{"robot", "person"}}}},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "match glob",
+			in: &Validator{
+				Validator: &Validator_Match_{
+					Match: &Validator_Match{
+						Claims: map[string]*Matcher{
+							"Role": {Matcher: &Matcher_Glob{Glob: "rob?t*"}},
+						},
+					},
+				},
+			},
+			ok: false,
+		},
+		{
+			name: "expression",
+			in: &Validator{
+				Validator: &Validator_Expression_{
+					Expression: &Validator_Expression{
+						Source: `has(identity.Role, "person") && !has(identity.OriginOrganization, banned)`,
+						Variables: map[string]*Value{
+							"banned": {Value: &Value_StringValue{StringValue: "Venus"}},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "visa",
+			in: &Validator{
+				Validator: &Validator_Visa_{
+					Visa: &Validator_Visa{Type: "ControlledAccessGrants", Value: "const:phs000710"},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "visa with unknown assertion time",
+			in: &Validator{
+				Validator: &Validator_Visa_{
+					Visa: &Validator_Visa{Type: "ControlledAccessGrants", Value: "const:phs000710", MaxAge: "24h"},
+				},
+			},
+			ok: false,
+		},
+		{
+			name: "visa pattern mismatch",
+			in: &Validator{
+				Validator: &Validator_Visa_{
+					Visa: &Validator_Visa{Type: "ControlledAccessGrants", Source: "pattern:*.example.com"},
+				},
+			},
+			ok: false,
+		},
+		{
+			name: "not",
+			in: &Validator{
+				Validator: &Validator_Not_{
+					Not: &Validator_Not{
+						Validator: &Validator{
+							Validator: &Validator_Simple_{
+								Simple: &Validator_Simple{
+									Claims: map[string]string{"Role": "robot"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "at least",
+			in: &Validator{
+				Validator: &Validator_AtLeast_{
+					AtLeast: &Validator_AtLeast{
+						N: 2,
+						Validators: []*Validator{
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "human"}}}},
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "robot"}}}},
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "person"}}}},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "exactly",
+			in: &Validator{
+				Validator: &Validator_Exactly_{
+					Exactly: &Validator_Exactly{
+						N: 1,
+						Validators: []*Validator{
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "human"}}}},
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "person"}}}},
+						},
+					},
+				},
+			},
+			ok: false,
+		},
 		{
 			name: "constant true",
 			in: &Validator{
@@ -100,6 +288,36 @@ func TestBuildValidator(t *testing.T) {
 			},
 			ok: true,
 		},
+		{
+			name: "parallel and",
+			in: &Validator{
+				Validator: &Validator_And_{
+					And: &Validator_And{
+						Parallel: true,
+						Validators: []*Validator{
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "human"}}}},
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "robot"}}}},
+						},
+					},
+				},
+			},
+			ok: false,
+		},
+		{
+			name: "parallel or",
+			in: &Validator{
+				Validator: &Validator_Or_{
+					Or: &Validator_Or{
+						Parallel: true,
+						Validators: []*Validator{
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "robot"}}}},
+							{Validator: &Validator_Simple_{Simple: &Validator_Simple{Claims: map[string]string{"Role": "human"}}}},
+						},
+					},
+				},
+			},
+			ok: true,
+		},
 		{
 			name: "boolean or",
 			in: &Validator{
@@ -144,3 +362,484 @@ func TestBuildValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSimpleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *Validator_Simple
+	}{
+		{
+			name: "unknown field",
+			in:   &Validator_Simple{Claims: map[string]string{"Rol": "human"}},
+		},
+		{
+			name: "unparseable bool",
+			in:   &Validator_Simple{Claims: map[string]string{"BonaFide": "yes please"}},
+		},
+		{
+			name: "string for bool field",
+			in: &Validator_Simple{TypedClaims: map[string]*Value{
+				"BonaFide": {Value: &Value_StringValue{StringValue: "true"}},
+			}},
+		},
+		{
+			name: "number for string field",
+			in: &Validator_Simple{TypedClaims: map[string]*Value{
+				"Role": {Value: &Value_NumberValue{NumberValue: 1}},
+			}},
+		},
+		{
+			name: "mismatch inside any_of",
+			in: &Validator_Simple{TypedClaims: map[string]*Value{
+				"Role": {Value: &Value_AnyOf_{AnyOf: &Value_AnyOf{Values: []*Value{
+					{Value: &Value_StringValue{StringValue: "human"}},
+					{Value: &Value_BoolValue{BoolValue: true}},
+				}}}},
+			}},
+		},
+		{
+			name: "duplicate claim",
+			in: &Validator_Simple{
+				Claims: map[string]string{"Role": "human"},
+				TypedClaims: map[string]*Value{
+					"Role": {Value: &Value_StringValue{StringValue: "human"}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := buildSimple(test.in); err == nil {
+				t.Fatal("buildSimple() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestBuildNoParser(t *testing.T) {
+	e := &Evaluator{Validator: &Validator{Validator: &Validator_Constant_{
+		Constant: &Validator_Constant{Value: true},
+	}}}
+	if _, err := Build(context.Background(), e); err == nil {
+		t.Fatal("Build() succeeded, want error")
+	}
+}
+
+func TestBuildMatchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *Validator_Match
+	}{
+		{
+			name: "unknown field",
+			in: &Validator_Match{Claims: map[string]*Matcher{
+				"Rol": {Matcher: &Matcher_Prefix{Prefix: "h"}},
+			}},
+		},
+		{
+			name: "non-string field",
+			in: &Validator_Match{Claims: map[string]*Matcher{
+				"BonaFide": {Matcher: &Matcher_Prefix{Prefix: "t"}},
+			}},
+		},
+		{
+			name: "invalid regexp",
+			in: &Validator_Match{Claims: map[string]*Matcher{
+				"Role": {Matcher: &Matcher_Regexp{Regexp: "(human"}},
+			}},
+		},
+		{
+			name: "no matcher",
+			in: &Validator_Match{Claims: map[string]*Matcher{
+				"Role": {},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := buildMatch(test.in); err == nil {
+				t.Fatal("buildMatch() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestExpressionValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *Value
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "string",
+			in:   &Value{Value: &Value_StringValue{StringValue: "a"}},
+			want: "a",
+		},
+		{
+			name: "list",
+			in: &Value{Value: &Value_AnyOf_{AnyOf: &Value_AnyOf{Values: []*Value{
+				{Value: &Value_NumberValue{NumberValue: 1}},
+				{Value: &Value_NumberValue{NumberValue: 2}},
+			}}}},
+			want: []float64{1, 2},
+		},
+		{
+			name: "mixed list",
+			in: &Value{Value: &Value_AnyOf_{AnyOf: &Value_AnyOf{Values: []*Value{
+				{Value: &Value_NumberValue{NumberValue: 1}},
+				{Value: &Value_BoolValue{BoolValue: true}},
+			}}}},
+			err: true,
+		},
+		{
+			name: "empty list",
+			in:   &Value{Value: &Value_AnyOf_{AnyOf: &Value_AnyOf{}}},
+			err:  true,
+		},
+		{
+			name: "no value",
+			in:   &Value{},
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := expressionValue(test.in)
+			if test.err != (err != nil) {
+				t.Fatalf("expressionValue() = %v, want error = %v", err, test.err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("expressionValue() = %#v, want = %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildTimed(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	timed := &ga4gh.Identity{
+		BonaFide: []ga4gh.BoolValue{
+			{Value: true, Asserted: now.Add(-48 * time.Hour).Unix(), Expires: now.Add(time.Hour).Unix()},
+		},
+	}
+	tests := []struct {
+		name string
+		in   *Validator_Timed_Claim
+		ok   bool
+	}{
+		{
+			name: "asserted recently",
+			in:   &Validator_Timed_Claim{Value: &Value{Value: &Value_BoolValue{BoolValue: true}}, MaxAge: "72h"},
+			ok:   true,
+		},
+		{
+			name: "asserted too long ago",
+			in:   &Validator_Timed_Claim{MaxAge: "24h"},
+			ok:   false,
+		},
+		{
+			name: "expires too soon",
+			in:   &Validator_Timed_Claim{MinRemaining: "2h"},
+			ok:   false,
+		},
+	}
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := buildTimed(&Validator_Timed{Claims: map[string]*Validator_Timed_Claim{"BonaFide": test.in}})
+			if err != nil {
+				t.Fatalf("buildTimed() = %v", err)
+			}
+			ok, err := v.Validate(ctx, timed)
+			if err != nil {
+				t.Fatalf("Error validating identity: %v", err)
+			}
+			if ok != test.ok {
+				t.Fatalf("Validate() = %v, want = %v", ok, test.ok)
+			}
+		})
+	}
+}
+
+func TestBuildTimedErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim string
+		in    *Validator_Timed_Claim
+	}{
+		{name: "unknown field", claim: "Rol", in: &Validator_Timed_Claim{MaxAge: "1h"}},
+		{name: "no timestamps", claim: "Issuer", in: &Validator_Timed_Claim{MaxAge: "1h"}},
+		{name: "bad max age", claim: "Role", in: &Validator_Timed_Claim{MaxAge: "a year"}},
+		{name: "bad min remaining", claim: "Role", in: &Validator_Timed_Claim{MinRemaining: "1 hour"}},
+		{
+			name:  "value type mismatch",
+			claim: "Role",
+			in:    &Validator_Timed_Claim{Value: &Value{Value: &Value_BoolValue{BoolValue: true}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := buildTimed(&Validator_Timed{Claims: map[string]*Validator_Timed_Claim{test.claim: test.in}}); err == nil {
+				t.Fatal("buildTimed() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestBuildDatasets(t *testing.T) {
+	const config = `
+		parser { shims { plugin { name: "test-static" } } }
+		validator { constant { value: true } }
+		datasets { key: "earth" value { simple { claims { key: "OriginOrganization" value: "Earth" } } } }
+		datasets { key: "venus" value { simple { claims { key: "OriginOrganization" value: "Venus" } } } }
+		datasets { key: "mars/*" value { simple { claims { key: "Role" value: "human" } } } }
+	`
+	var e Evaluator
+	if err := proto.UnmarshalText(config, &e); err != nil {
+		t.Fatalf("Error parsing evaluator: %v", err)
+	}
+	ctx := context.Background()
+	ev, err := Build(ctx, &e)
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+	if ev.Cache != nil {
+		t.Fatal("Build() created a cache without configuration")
+	}
+	identity, err := ev.Evaluate(ctx, "token")
+	if err != nil {
+		t.Fatalf("Evaluate() = %v", err)
+	}
+	tests := []struct {
+		dataset string
+		ok      bool
+	}{
+		{dataset: "earth", ok: true},
+		{dataset: "venus", ok: false},
+		{dataset: "mars/olympus", ok: true},
+		{dataset: "jupiter", ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.dataset, func(t *testing.T) {
+			ok, err := ev.Authorize(ctx, identity, test.dataset)
+			if err != nil {
+				t.Fatalf("Authorize() = %v", err)
+			}
+			if ok != test.ok {
+				t.Fatalf("Authorize() = %v, want = %v", ok, test.ok)
+			}
+		})
+	}
+}
+
+func TestBuildCache(t *testing.T) {
+	const config = `
+		parser { shims { plugin { name: "test-static" } } }
+		validator { constant { value: true } }
+		cache { max_entries: 10 ttl: "1m" }
+	`
+	var e Evaluator
+	if err := proto.UnmarshalText(config, &e); err != nil {
+		t.Fatalf("Error parsing evaluator: %v", err)
+	}
+	ctx := context.Background()
+	ev, err := Build(ctx, &e)
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := ev.Evaluate(ctx, "token"); err != nil {
+			t.Fatalf("Evaluate() = %v", err)
+		}
+	}
+	if stats := ev.Cache.Stats(); stats.Hits != 1 || stats.Entries != 1 {
+		t.Fatalf("Stats() = %+v, want 1 hit and 1 entry", stats)
+	}
+}
+
+func TestBuildGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "groups")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "groups.csv")
+	if err := ioutil.WriteFile(path, []byte("https://idp.example.com,alice,cohort-a\n"), 0644); err != nil {
+		t.Fatalf("Error writing group file: %v", err)
+	}
+	file := &GroupFile{Path: path, ReloadInterval: "1m"}
+	alice := &ga4gh.Identity{Issuer: "https://idp.example.com", Subject: "alice"}
+	ctx := context.Background()
+
+	v, err := buildValidator(ctx, &Validator{Validator: &Validator_Groups_{Groups: &Validator_Groups{File: file, Groups: []string{"cohort-a"}}}})
+	if err != nil {
+		t.Fatalf("buildValidator() = %v", err)
+	}
+	if ok, err := v.Validate(ctx, alice); !ok || err != nil {
+		t.Fatalf("Validate() = (%v, %v), want = (true, nil)", ok, err)
+	}
+
+	e, err := buildEnricher(ctx, &Enricher{Enricher: &Enricher_Groups_{Groups: &Enricher_Groups{File: file}}})
+	if err != nil {
+		t.Fatalf("buildEnricher() = %v", err)
+	}
+	enriched, err := e.Enrich(ctx, alice)
+	if err != nil {
+		t.Fatalf("Enrich() = %v", err)
+	}
+	simple := validator.Simple{"Groups": "cohort-a"}
+	if ok, err := simple.Validate(ctx, enriched); !ok || err != nil {
+		t.Fatalf("Validate() of enriched identity = (%v, %v), want = (true, nil)", ok, err)
+	}
+	if want := []ga4gh.StringValue{{Value: "cohort-a", Source: path}}; !reflect.DeepEqual(enriched.Groups, want) {
+		t.Fatalf("Enrich() groups = %+v, want = %+v", enriched.Groups, want)
+	}
+
+	invalid := []*Validator_Groups{
+		{File: file},
+		{File: &GroupFile{Path: filepath.Join(dir, "absent.csv")}, Groups: []string{"cohort-a"}},
+		{File: &GroupFile{Path: path, ReloadInterval: "often"}, Groups: []string{"cohort-a"}},
+	}
+	for _, g := range invalid {
+		if _, err := buildGroups(g); err == nil {
+			t.Errorf("buildGroups(%v) succeeded, want error", g)
+		}
+	}
+}
+
+func TestBuildDenyList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "denylist")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "denied.txt")
+	if err := ioutil.WriteFile(path, []byte("sub mallory\n"), 0644); err != nil {
+		t.Fatalf("Error writing deny list: %v", err)
+	}
+	ctx := context.Background()
+
+	d, err := buildDenyList(ctx, &DenyList{DenyList: &DenyList_File_{File: &DenyList_File{Path: path, ReloadInterval: "1m"}}})
+	if err != nil {
+		t.Fatalf("buildDenyList() = %v", err)
+	}
+	for subject, want := range map[string]bool{"mallory": true, "alice": false} {
+		if got, err := d.Denied(ctx, ga4gh.TokenID{Subject: subject}); got != want || err != nil {
+			t.Errorf("Denied(%q) = (%v, %v), want = (%v, nil)", subject, got, err, want)
+		}
+	}
+
+	invalid := []*DenyList_File{
+		{},
+		{Path: filepath.Join(dir, "absent.txt")},
+		{Path: path, ReloadInterval: "often"},
+	}
+	for _, f := range invalid {
+		if _, err := buildDenyList(ctx, &DenyList{DenyList: &DenyList_File_{File: f}}); err == nil {
+			t.Errorf("buildDenyList(%v) succeeded, want error", f)
+		}
+	}
+}
+
+func TestIssuerOptions(t *testing.T) {
+	p := &Parser{
+		Issuers: map[string]string{"https://idp.example.com": "client"},
+		IssuerOptions: map[string]*IssuerOptions{
+			"https://other.example.com": {
+				Audiences:      []string{"a", "b"},
+				Algorithms:     []string{"ES256"},
+				ClockSkew:      "30s",
+				MaxAge:         "1h",
+				RequiredClaims: []string{"azp"},
+			},
+		},
+	}
+	got, err := issuerOptions(p)
+	if err != nil {
+		t.Fatalf("issuerOptions() = %v", err)
+	}
+	want := map[string]ga4gh.IssuerOptions{
+		"https://idp.example.com": {Audiences: []string{"client"}},
+		"https://other.example.com": {
+			Audiences:      []string{"a", "b"},
+			Algorithms:     []string{"ES256"},
+			ClockSkew:      30 * time.Second,
+			MaxAge:         time.Hour,
+			RequiredClaims: []string{"azp"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("issuerOptions() = %+v, want = %+v", got, want)
+	}
+
+	invalid := []*Parser{
+		{
+			Issuers:       map[string]string{"https://idp.example.com": "client"},
+			IssuerOptions: map[string]*IssuerOptions{"https://idp.example.com": {Audiences: []string{"client"}}},
+		},
+		{IssuerOptions: map[string]*IssuerOptions{"https://idp.example.com": {Audiences: []string{"client"}, ClockSkew: "a little"}}},
+		{IssuerOptions: map[string]*IssuerOptions{"https://idp.example.com": {Audiences: []string{"client"}, MaxAge: "forever"}}},
+	}
+	for _, p := range invalid {
+		if _, err := issuerOptions(p); err == nil {
+			t.Errorf("issuerOptions(%v) succeeded, want error", p)
+		}
+	}
+}
+
+func TestBuildIntrospection(t *testing.T) {
+	ctx := context.Background()
+	valid := &Shim_Introspection{
+		Endpoint:     "https://idp.example.com/introspect",
+		Issuer:       "https://idp.example.com",
+		ClientId:     "client",
+		ClientSecret: "secret",
+		Timeout:      "5s",
+		CacheEntries: 10,
+	}
+	if _, err := buildShim(ctx, &Shim{Shim: &Shim_Introspection_{Introspection: valid}}); err != nil {
+		t.Fatalf("buildShim() = %v", err)
+	}
+
+	invalid := []*Shim_Introspection{
+		{Endpoint: valid.Endpoint, Issuer: valid.Issuer, ClientId: valid.ClientId, Timeout: "soon"},
+		{Endpoint: valid.Endpoint, ClientId: valid.ClientId},
+		{Issuer: valid.Issuer, ClientId: valid.ClientId},
+	}
+	for _, i := range invalid {
+		if _, err := buildShim(ctx, &Shim{Shim: &Shim_Introspection_{Introspection: i}}); err == nil {
+			t.Errorf("buildShim(%v) succeeded, want error", i)
+		}
+	}
+}
+
+func TestBuildUserInfo(t *testing.T) {
+	ctx := context.Background()
+	e, err := buildEnricher(ctx, &Enricher{Enricher: &Enricher_UserInfo_{UserInfo: &Enricher_UserInfo{
+		Issuer:   "https://idp.example.com",
+		Endpoint: "https://idp.example.com/userinfo",
+		Timeout:  "5s",
+		CacheTtl: "1m",
+	}}})
+	if err != nil {
+		t.Fatalf("buildEnricher() = %v", err)
+	}
+	// Identities of other issuers are not enriched, so no request is made.
+	other := &ga4gh.Identity{Issuer: "https://other.example.com", Subject: "alice"}
+	if got, err := e.Enrich(ctx, other); got != other || err != nil {
+		t.Fatalf("Enrich() = (%+v, %v), want the identity unchanged", got, err)
+	}
+
+	invalid := []*Enricher_UserInfo{
+		{Endpoint: "https://idp.example.com/userinfo"},
+		{Issuer: "https://idp.example.com", Endpoint: "https://idp.example.com/userinfo", Timeout: "soon"},
+		{Issuer: "https://idp.example.com", Endpoint: "https://idp.example.com/userinfo", CacheTtl: "forever"},
+		{Issuer: "https://idp.example.com", Endpoint: "https://idp.example.com/userinfo", Format: 7},
+	}
+	for _, u := range invalid {
+		if _, err := buildEnricher(ctx, &Enricher{Enricher: &Enricher_UserInfo_{UserInfo: u}}); err == nil {
+			t.Errorf("buildEnricher(%v) succeeded, want error", u)
+		}
+	}
+}
@@ -26,6 +26,7 @@ types from the ga4gh package, allowing builder.Build the ability to build
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -38,19 +39,54 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Format is the format of the claims returned by the endpoint.
+type Enricher_UserInfo_Format int32
+
+const (
+	// The JSON format of ga4gh.Identity, from which visas are never read.
+	Enricher_UserInfo_GA4GH Enricher_UserInfo_Format = 0
+	// The claims of ELIXIR, which are mapped as by the elixir shim.  See
+	// elixir.Decoder.
+	Enricher_UserInfo_ELIXIR Enricher_UserInfo_Format = 1
+)
+
+var Enricher_UserInfo_Format_name = map[int32]string{
+	0: "GA4GH",
+	1: "ELIXIR",
+}
+var Enricher_UserInfo_Format_value = map[string]int32{
+	"GA4GH":  0,
+	"ELIXIR": 1,
+}
+
+func (x Enricher_UserInfo_Format) String() string {
+	return proto.EnumName(Enricher_UserInfo_Format_name, int32(x))
+}
+func (Enricher_UserInfo_Format) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{8, 1, 0}
+}
+
 type Parser struct {
-	Shims                []*Shim           `protobuf:"bytes,1,rep,name=shims,proto3" json:"shims,omitempty"`
-	Issuers              map[string]string `protobuf:"bytes,2,rep,name=issuers,proto3" json:"issuers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Shims []*Shim `protobuf:"bytes,1,rep,name=shims,proto3" json:"shims,omitempty"`
+	// OAuth 2.0 issuer base URLs and the client ID that their tokens must be
+	// issued to.  Each entry is equivalent to an entry of issuer_options with
+	// a single audience.
+	Issuers map[string]string `protobuf:"bytes,2,rep,name=issuers,proto3" json:"issuers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tokens on the deny list are rejected even if they are otherwise valid.
+	DenyList *DenyList `protobuf:"bytes,3,opt,name=deny_list,json=denyList,proto3" json:"deny_list,omitempty"`
+	// OAuth 2.0 issuer base URLs and how their tokens are verified.  An issuer
+	// may not be listed in both issuers and issuer_options.
+	IssuerOptions        map[string]*IssuerOptions `protobuf:"bytes,4,rep,name=issuer_options,json=issuerOptions,proto3" json:"issuer_options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *Parser) Reset()         { *m = Parser{} }
 func (m *Parser) String() string { return proto.CompactTextString(m) }
 func (*Parser) ProtoMessage()    {}
 func (*Parser) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{0}
+	return fileDescriptor_builder_80e69917672a2222, []int{0}
 }
 func (m *Parser) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Parser.Unmarshal(m, b)
@@ -84,9 +120,301 @@ func (m *Parser) GetIssuers() map[string]string {
 	return nil
 }
 
+func (m *Parser) GetDenyList() *DenyList {
+	if m != nil {
+		return m.DenyList
+	}
+	return nil
+}
+
+func (m *Parser) GetIssuerOptions() map[string]*IssuerOptions {
+	if m != nil {
+		return m.IssuerOptions
+	}
+	return nil
+}
+
+// IssuerOptions control how the tokens of an issuer are verified.  See
+// ga4gh.IssuerOptions.
+type IssuerOptions struct {
+	// The client IDs that tokens may be issued to.  At least one is required.
+	Audiences []string `protobuf:"bytes,1,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// The accepted signing algorithms.  Defaults to RS256.
+	Algorithms []string `protobuf:"bytes,2,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	// The tolerated clock skew, in the format accepted by time.ParseDuration.
+	ClockSkew string `protobuf:"bytes,3,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`
+	// The longest time after a token was issued that it is accepted for, in
+	// the format accepted by time.ParseDuration.  If unset, the age of tokens
+	// is not limited.
+	MaxAge string `protobuf:"bytes,4,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Claims that tokens must contain with a non-empty value, such as "azp" or
+	// "nonce".
+	RequiredClaims       []string `protobuf:"bytes,5,rep,name=required_claims,json=requiredClaims,proto3" json:"required_claims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuerOptions) Reset()         { *m = IssuerOptions{} }
+func (m *IssuerOptions) String() string { return proto.CompactTextString(m) }
+func (*IssuerOptions) ProtoMessage()    {}
+func (*IssuerOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{1}
+}
+func (m *IssuerOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuerOptions.Unmarshal(m, b)
+}
+func (m *IssuerOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuerOptions.Marshal(b, m, deterministic)
+}
+func (dst *IssuerOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuerOptions.Merge(dst, src)
+}
+func (m *IssuerOptions) XXX_Size() int {
+	return xxx_messageInfo_IssuerOptions.Size(m)
+}
+func (m *IssuerOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuerOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuerOptions proto.InternalMessageInfo
+
+func (m *IssuerOptions) GetAudiences() []string {
+	if m != nil {
+		return m.Audiences
+	}
+	return nil
+}
+
+func (m *IssuerOptions) GetAlgorithms() []string {
+	if m != nil {
+		return m.Algorithms
+	}
+	return nil
+}
+
+func (m *IssuerOptions) GetClockSkew() string {
+	if m != nil {
+		return m.ClockSkew
+	}
+	return ""
+}
+
+func (m *IssuerOptions) GetMaxAge() string {
+	if m != nil {
+		return m.MaxAge
+	}
+	return ""
+}
+
+func (m *IssuerOptions) GetRequiredClaims() []string {
+	if m != nil {
+		return m.RequiredClaims
+	}
+	return nil
+}
+
+// DenyList rejects revoked tokens.  See ga4gh.DenyList.
+type DenyList struct {
+	// Types that are valid to be assigned to DenyList:
+	//	*DenyList_File_
+	//	*DenyList_Plugin
+	DenyList             isDenyList_DenyList `protobuf_oneof:"deny_list"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DenyList) Reset()         { *m = DenyList{} }
+func (m *DenyList) String() string { return proto.CompactTextString(m) }
+func (*DenyList) ProtoMessage()    {}
+func (*DenyList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{2}
+}
+func (m *DenyList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DenyList.Unmarshal(m, b)
+}
+func (m *DenyList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DenyList.Marshal(b, m, deterministic)
+}
+func (dst *DenyList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenyList.Merge(dst, src)
+}
+func (m *DenyList) XXX_Size() int {
+	return xxx_messageInfo_DenyList.Size(m)
+}
+func (m *DenyList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenyList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DenyList proto.InternalMessageInfo
+
+type isDenyList_DenyList interface {
+	isDenyList_DenyList()
+}
+
+type DenyList_File_ struct {
+	File *DenyList_File `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type DenyList_Plugin struct {
+	Plugin *Plugin `protobuf:"bytes,2,opt,name=plugin,proto3,oneof"`
+}
+
+func (*DenyList_File_) isDenyList_DenyList() {}
+
+func (*DenyList_Plugin) isDenyList_DenyList() {}
+
+func (m *DenyList) GetDenyList() isDenyList_DenyList {
+	if m != nil {
+		return m.DenyList
+	}
+	return nil
+}
+
+func (m *DenyList) GetFile() *DenyList_File {
+	if x, ok := m.GetDenyList().(*DenyList_File_); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (m *DenyList) GetPlugin() *Plugin {
+	if x, ok := m.GetDenyList().(*DenyList_Plugin); ok {
+		return x.Plugin
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*DenyList) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _DenyList_OneofMarshaler, _DenyList_OneofUnmarshaler, _DenyList_OneofSizer, []interface{}{
+		(*DenyList_File_)(nil),
+		(*DenyList_Plugin)(nil),
+	}
+}
+
+func _DenyList_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*DenyList)
+	// deny_list
+	switch x := m.DenyList.(type) {
+	case *DenyList_File_:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.File); err != nil {
+			return err
+		}
+	case *DenyList_Plugin:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Plugin); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("DenyList.DenyList has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _DenyList_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*DenyList)
+	switch tag {
+	case 1: // deny_list.file
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DenyList_File)
+		err := b.DecodeMessage(msg)
+		m.DenyList = &DenyList_File_{msg}
+		return true, err
+	case 2: // deny_list.plugin
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Plugin)
+		err := b.DecodeMessage(msg)
+		m.DenyList = &DenyList_Plugin{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _DenyList_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*DenyList)
+	// deny_list
+	switch x := m.DenyList.(type) {
+	case *DenyList_File_:
+		s := proto.Size(x.File)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *DenyList_Plugin:
+		s := proto.Size(x.Plugin)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// File denies the token IDs, subjects and issuer and subject pairs listed
+// in a file.  See denylist.File for the format.
+type DenyList_File struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// How often the file is checked for changes, in the format accepted by
+	// time.ParseDuration.  Defaults to denylist.DefaultReloadInterval.
+	ReloadInterval       string   `protobuf:"bytes,2,opt,name=reload_interval,json=reloadInterval,proto3" json:"reload_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DenyList_File) Reset()         { *m = DenyList_File{} }
+func (m *DenyList_File) String() string { return proto.CompactTextString(m) }
+func (*DenyList_File) ProtoMessage()    {}
+func (*DenyList_File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{2, 0}
+}
+func (m *DenyList_File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DenyList_File.Unmarshal(m, b)
+}
+func (m *DenyList_File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DenyList_File.Marshal(b, m, deterministic)
+}
+func (dst *DenyList_File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenyList_File.Merge(dst, src)
+}
+func (m *DenyList_File) XXX_Size() int {
+	return xxx_messageInfo_DenyList_File.Size(m)
+}
+func (m *DenyList_File) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenyList_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DenyList_File proto.InternalMessageInfo
+
+func (m *DenyList_File) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DenyList_File) GetReloadInterval() string {
+	if m != nil {
+		return m.ReloadInterval
+	}
+	return ""
+}
+
 type Shim struct {
 	// Types that are valid to be assigned to Shim:
 	//	*Shim_Elixir_
+	//	*Shim_Plugin
+	//	*Shim_Introspection_
 	Shim                 isShim_Shim `protobuf_oneof:"shim"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
@@ -97,7 +425,7 @@ func (m *Shim) Reset()         { *m = Shim{} }
 func (m *Shim) String() string { return proto.CompactTextString(m) }
 func (*Shim) ProtoMessage()    {}
 func (*Shim) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{1}
+	return fileDescriptor_builder_80e69917672a2222, []int{3}
 }
 func (m *Shim) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Shim.Unmarshal(m, b)
@@ -125,8 +453,20 @@ type Shim_Elixir_ struct {
 	Elixir *Shim_Elixir `protobuf:"bytes,1,opt,name=elixir,proto3,oneof"`
 }
 
+type Shim_Plugin struct {
+	Plugin *Plugin `protobuf:"bytes,2,opt,name=plugin,proto3,oneof"`
+}
+
+type Shim_Introspection_ struct {
+	Introspection *Shim_Introspection `protobuf:"bytes,3,opt,name=introspection,proto3,oneof"`
+}
+
 func (*Shim_Elixir_) isShim_Shim() {}
 
+func (*Shim_Plugin) isShim_Shim() {}
+
+func (*Shim_Introspection_) isShim_Shim() {}
+
 func (m *Shim) GetShim() isShim_Shim {
 	if m != nil {
 		return m.Shim
@@ -141,10 +481,26 @@ func (m *Shim) GetElixir() *Shim_Elixir {
 	return nil
 }
 
+func (m *Shim) GetPlugin() *Plugin {
+	if x, ok := m.GetShim().(*Shim_Plugin); ok {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (m *Shim) GetIntrospection() *Shim_Introspection {
+	if x, ok := m.GetShim().(*Shim_Introspection_); ok {
+		return x.Introspection
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Shim) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Shim_OneofMarshaler, _Shim_OneofUnmarshaler, _Shim_OneofSizer, []interface{}{
 		(*Shim_Elixir_)(nil),
+		(*Shim_Plugin)(nil),
+		(*Shim_Introspection_)(nil),
 	}
 }
 
@@ -157,6 +513,16 @@ func _Shim_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Elixir); err != nil {
 			return err
 		}
+	case *Shim_Plugin:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Plugin); err != nil {
+			return err
+		}
+	case *Shim_Introspection_:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Introspection); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Shim.Shim has unexpected type %T", x)
@@ -175,6 +541,22 @@ func _Shim_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (
 		err := b.DecodeMessage(msg)
 		m.Shim = &Shim_Elixir_{msg}
 		return true, err
+	case 2: // shim.plugin
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Plugin)
+		err := b.DecodeMessage(msg)
+		m.Shim = &Shim_Plugin{msg}
+		return true, err
+	case 3: // shim.introspection
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Shim_Introspection)
+		err := b.DecodeMessage(msg)
+		m.Shim = &Shim_Introspection_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -189,6 +571,16 @@ func _Shim_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Shim_Plugin:
+		s := proto.Size(x.Plugin)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Shim_Introspection_:
+		s := proto.Size(x.Introspection)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -207,7 +599,7 @@ func (m *Shim_Elixir) Reset()         { *m = Shim_Elixir{} }
 func (m *Shim_Elixir) String() string { return proto.CompactTextString(m) }
 func (*Shim_Elixir) ProtoMessage()    {}
 func (*Shim_Elixir) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{1, 0}
+	return fileDescriptor_builder_80e69917672a2222, []int{3, 0}
 }
 func (m *Shim_Elixir) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Shim_Elixir.Unmarshal(m, b)
@@ -234,12 +626,107 @@ func (m *Shim_Elixir) GetClientId() string {
 	return ""
 }
 
+// Introspection converts opaque access tokens using an OAuth 2.0 token
+// introspection endpoint.  Every opaque token the parser receives is sent
+// to the endpoint.  See introspection.Config.
+type Shim_Introspection struct {
+	Endpoint     string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Issuer       string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId     string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	// The timeout of each request, in the format accepted by
+	// time.ParseDuration.  Defaults to introspection.DefaultTimeout.
+	Timeout string `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// The most results to cache.  Defaults to
+	// introspection.DefaultCacheEntries.
+	CacheEntries         uint32   `protobuf:"varint,6,opt,name=cache_entries,json=cacheEntries,proto3" json:"cache_entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Shim_Introspection) Reset()         { *m = Shim_Introspection{} }
+func (m *Shim_Introspection) String() string { return proto.CompactTextString(m) }
+func (*Shim_Introspection) ProtoMessage()    {}
+func (*Shim_Introspection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{3, 1}
+}
+func (m *Shim_Introspection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Shim_Introspection.Unmarshal(m, b)
+}
+func (m *Shim_Introspection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Shim_Introspection.Marshal(b, m, deterministic)
+}
+func (dst *Shim_Introspection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Shim_Introspection.Merge(dst, src)
+}
+func (m *Shim_Introspection) XXX_Size() int {
+	return xxx_messageInfo_Shim_Introspection.Size(m)
+}
+func (m *Shim_Introspection) XXX_DiscardUnknown() {
+	xxx_messageInfo_Shim_Introspection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Shim_Introspection proto.InternalMessageInfo
+
+func (m *Shim_Introspection) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Shim_Introspection) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *Shim_Introspection) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *Shim_Introspection) GetClientSecret() string {
+	if m != nil {
+		return m.ClientSecret
+	}
+	return ""
+}
+
+func (m *Shim_Introspection) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *Shim_Introspection) GetCacheEntries() uint32 {
+	if m != nil {
+		return m.CacheEntries
+	}
+	return 0
+}
+
 type Validator struct {
 	// Types that are valid to be assigned to Validator:
 	//	*Validator_And_
 	//	*Validator_Or_
 	//	*Validator_Simple_
 	//	*Validator_Constant_
+	//	*Validator_Plugin
+	//	*Validator_Sourced_
+	//	*Validator_Not_
+	//	*Validator_AtLeast_
+	//	*Validator_Exactly_
+	//	*Validator_Match_
+	//	*Validator_Expression_
+	//	*Validator_Timed_
+	//	*Validator_Visa_
+	//	*Validator_Groups_
 	Validator            isValidator_Validator `protobuf_oneof:"validator"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
@@ -250,7 +737,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{2}
+	return fileDescriptor_builder_80e69917672a2222, []int{4}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator.Unmarshal(m, b)
@@ -290,29 +777,89 @@ type Validator_Constant_ struct {
 	Constant *Validator_Constant `protobuf:"bytes,4,opt,name=constant,proto3,oneof"`
 }
 
-func (*Validator_And_) isValidator_Validator() {}
+type Validator_Plugin struct {
+	Plugin *Plugin `protobuf:"bytes,5,opt,name=plugin,proto3,oneof"`
+}
 
-func (*Validator_Or_) isValidator_Validator() {}
+type Validator_Sourced_ struct {
+	Sourced *Validator_Sourced `protobuf:"bytes,6,opt,name=sourced,proto3,oneof"`
+}
 
-func (*Validator_Simple_) isValidator_Validator() {}
+type Validator_Not_ struct {
+	Not *Validator_Not `protobuf:"bytes,7,opt,name=not,proto3,oneof"`
+}
 
-func (*Validator_Constant_) isValidator_Validator() {}
+type Validator_AtLeast_ struct {
+	AtLeast *Validator_AtLeast `protobuf:"bytes,8,opt,name=at_least,json=atLeast,proto3,oneof"`
+}
 
-func (m *Validator) GetValidator() isValidator_Validator {
-	if m != nil {
-		return m.Validator
-	}
-	return nil
+type Validator_Exactly_ struct {
+	Exactly *Validator_Exactly `protobuf:"bytes,9,opt,name=exactly,proto3,oneof"`
 }
 
-func (m *Validator) GetAnd() *Validator_And {
-	if x, ok := m.GetValidator().(*Validator_And_); ok {
-		return x.And
-	}
-	return nil
+type Validator_Match_ struct {
+	Match *Validator_Match `protobuf:"bytes,10,opt,name=match,proto3,oneof"`
 }
 
-func (m *Validator) GetOr() *Validator_Or {
+type Validator_Expression_ struct {
+	Expression *Validator_Expression `protobuf:"bytes,11,opt,name=expression,proto3,oneof"`
+}
+
+type Validator_Timed_ struct {
+	Timed *Validator_Timed `protobuf:"bytes,12,opt,name=timed,proto3,oneof"`
+}
+
+type Validator_Visa_ struct {
+	Visa *Validator_Visa `protobuf:"bytes,13,opt,name=visa,proto3,oneof"`
+}
+
+type Validator_Groups_ struct {
+	Groups *Validator_Groups `protobuf:"bytes,14,opt,name=groups,proto3,oneof"`
+}
+
+func (*Validator_And_) isValidator_Validator() {}
+
+func (*Validator_Or_) isValidator_Validator() {}
+
+func (*Validator_Simple_) isValidator_Validator() {}
+
+func (*Validator_Constant_) isValidator_Validator() {}
+
+func (*Validator_Plugin) isValidator_Validator() {}
+
+func (*Validator_Sourced_) isValidator_Validator() {}
+
+func (*Validator_Not_) isValidator_Validator() {}
+
+func (*Validator_AtLeast_) isValidator_Validator() {}
+
+func (*Validator_Exactly_) isValidator_Validator() {}
+
+func (*Validator_Match_) isValidator_Validator() {}
+
+func (*Validator_Expression_) isValidator_Validator() {}
+
+func (*Validator_Timed_) isValidator_Validator() {}
+
+func (*Validator_Visa_) isValidator_Validator() {}
+
+func (*Validator_Groups_) isValidator_Validator() {}
+
+func (m *Validator) GetValidator() isValidator_Validator {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *Validator) GetAnd() *Validator_And {
+	if x, ok := m.GetValidator().(*Validator_And_); ok {
+		return x.And
+	}
+	return nil
+}
+
+func (m *Validator) GetOr() *Validator_Or {
 	if x, ok := m.GetValidator().(*Validator_Or_); ok {
 		return x.Or
 	}
@@ -333,6 +880,76 @@ func (m *Validator) GetConstant() *Validator_Constant {
 	return nil
 }
 
+func (m *Validator) GetPlugin() *Plugin {
+	if x, ok := m.GetValidator().(*Validator_Plugin); ok {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (m *Validator) GetSourced() *Validator_Sourced {
+	if x, ok := m.GetValidator().(*Validator_Sourced_); ok {
+		return x.Sourced
+	}
+	return nil
+}
+
+func (m *Validator) GetNot() *Validator_Not {
+	if x, ok := m.GetValidator().(*Validator_Not_); ok {
+		return x.Not
+	}
+	return nil
+}
+
+func (m *Validator) GetAtLeast() *Validator_AtLeast {
+	if x, ok := m.GetValidator().(*Validator_AtLeast_); ok {
+		return x.AtLeast
+	}
+	return nil
+}
+
+func (m *Validator) GetExactly() *Validator_Exactly {
+	if x, ok := m.GetValidator().(*Validator_Exactly_); ok {
+		return x.Exactly
+	}
+	return nil
+}
+
+func (m *Validator) GetMatch() *Validator_Match {
+	if x, ok := m.GetValidator().(*Validator_Match_); ok {
+		return x.Match
+	}
+	return nil
+}
+
+func (m *Validator) GetExpression() *Validator_Expression {
+	if x, ok := m.GetValidator().(*Validator_Expression_); ok {
+		return x.Expression
+	}
+	return nil
+}
+
+func (m *Validator) GetTimed() *Validator_Timed {
+	if x, ok := m.GetValidator().(*Validator_Timed_); ok {
+		return x.Timed
+	}
+	return nil
+}
+
+func (m *Validator) GetVisa() *Validator_Visa {
+	if x, ok := m.GetValidator().(*Validator_Visa_); ok {
+		return x.Visa
+	}
+	return nil
+}
+
+func (m *Validator) GetGroups() *Validator_Groups {
+	if x, ok := m.GetValidator().(*Validator_Groups_); ok {
+		return x.Groups
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Validator) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Validator_OneofMarshaler, _Validator_OneofUnmarshaler, _Validator_OneofSizer, []interface{}{
@@ -340,6 +957,16 @@ func (*Validator) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) err
 		(*Validator_Or_)(nil),
 		(*Validator_Simple_)(nil),
 		(*Validator_Constant_)(nil),
+		(*Validator_Plugin)(nil),
+		(*Validator_Sourced_)(nil),
+		(*Validator_Not_)(nil),
+		(*Validator_AtLeast_)(nil),
+		(*Validator_Exactly_)(nil),
+		(*Validator_Match_)(nil),
+		(*Validator_Expression_)(nil),
+		(*Validator_Timed_)(nil),
+		(*Validator_Visa_)(nil),
+		(*Validator_Groups_)(nil),
 	}
 }
 
@@ -367,6 +994,56 @@ func _Validator_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Constant); err != nil {
 			return err
 		}
+	case *Validator_Plugin:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Plugin); err != nil {
+			return err
+		}
+	case *Validator_Sourced_:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Sourced); err != nil {
+			return err
+		}
+	case *Validator_Not_:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Not); err != nil {
+			return err
+		}
+	case *Validator_AtLeast_:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AtLeast); err != nil {
+			return err
+		}
+	case *Validator_Exactly_:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Exactly); err != nil {
+			return err
+		}
+	case *Validator_Match_:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Match); err != nil {
+			return err
+		}
+	case *Validator_Expression_:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Expression); err != nil {
+			return err
+		}
+	case *Validator_Timed_:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Timed); err != nil {
+			return err
+		}
+	case *Validator_Visa_:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Visa); err != nil {
+			return err
+		}
+	case *Validator_Groups_:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Groups); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Validator.Validator has unexpected type %T", x)
@@ -409,6 +1086,86 @@ func _Validator_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buff
 		err := b.DecodeMessage(msg)
 		m.Validator = &Validator_Constant_{msg}
 		return true, err
+	case 5: // validator.plugin
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Plugin)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Plugin{msg}
+		return true, err
+	case 6: // validator.sourced
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Sourced)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Sourced_{msg}
+		return true, err
+	case 7: // validator.not
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Not)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Not_{msg}
+		return true, err
+	case 8: // validator.at_least
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_AtLeast)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_AtLeast_{msg}
+		return true, err
+	case 9: // validator.exactly
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Exactly)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Exactly_{msg}
+		return true, err
+	case 10: // validator.match
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Match)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Match_{msg}
+		return true, err
+	case 11: // validator.expression
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Expression)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Expression_{msg}
+		return true, err
+	case 12: // validator.timed
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Timed)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Timed_{msg}
+		return true, err
+	case 13: // validator.visa
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Visa)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Visa_{msg}
+		return true, err
+	case 14: // validator.groups
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Validator_Groups)
+		err := b.DecodeMessage(msg)
+		m.Validator = &Validator_Groups_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -438,6 +1195,56 @@ func _Validator_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Validator_Plugin:
+		s := proto.Size(x.Plugin)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Sourced_:
+		s := proto.Size(x.Sourced)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Not_:
+		s := proto.Size(x.Not)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_AtLeast_:
+		s := proto.Size(x.AtLeast)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Exactly_:
+		s := proto.Size(x.Exactly)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Match_:
+		s := proto.Size(x.Match)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Expression_:
+		s := proto.Size(x.Expression)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Timed_:
+		s := proto.Size(x.Timed)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Visa_:
+		s := proto.Size(x.Visa)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Validator_Groups_:
+		s := proto.Size(x.Groups)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -446,17 +1253,19 @@ func _Validator_OneofSizer(msg proto.Message) (n int) {
 }
 
 type Validator_And struct {
-	Validators           []*Validator `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Validators []*Validator `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	// Invoke the validators concurrently rather than one at a time.
+	Parallel             bool     `protobuf:"varint,2,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Validator_And) Reset()         { *m = Validator_And{} }
 func (m *Validator_And) String() string { return proto.CompactTextString(m) }
 func (*Validator_And) ProtoMessage()    {}
 func (*Validator_And) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{2, 0}
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 0}
 }
 func (m *Validator_And) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator_And.Unmarshal(m, b)
@@ -483,18 +1292,27 @@ func (m *Validator_And) GetValidators() []*Validator {
 	return nil
 }
 
+func (m *Validator_And) GetParallel() bool {
+	if m != nil {
+		return m.Parallel
+	}
+	return false
+}
+
 type Validator_Or struct {
-	Validators           []*Validator `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Validators []*Validator `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"`
+	// Invoke the validators concurrently rather than one at a time.
+	Parallel             bool     `protobuf:"varint,3,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Validator_Or) Reset()         { *m = Validator_Or{} }
 func (m *Validator_Or) String() string { return proto.CompactTextString(m) }
 func (*Validator_Or) ProtoMessage()    {}
 func (*Validator_Or) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{2, 1}
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 1}
 }
 func (m *Validator_Or) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator_Or.Unmarshal(m, b)
@@ -521,8 +1339,20 @@ func (m *Validator_Or) GetValidators() []*Validator {
 	return nil
 }
 
+func (m *Validator_Or) GetParallel() bool {
+	if m != nil {
+		return m.Parallel
+	}
+	return false
+}
+
 type Validator_Simple struct {
-	Claims               map[string]string `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Claims whose values are converted from strings to the type of the
+	// corresponding ga4gh.Identity field, for example "true" for BonaFide.
+	Claims map[string]string `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Claims with explicitly typed values.  A key may not appear in both
+	// claims and typed_claims.
+	TypedClaims          map[string]*Value `protobuf:"bytes,2,rep,name=typed_claims,json=typedClaims,proto3" json:"typed_claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -532,7 +1362,7 @@ func (m *Validator_Simple) Reset()         { *m = Validator_Simple{} }
 func (m *Validator_Simple) String() string { return proto.CompactTextString(m) }
 func (*Validator_Simple) ProtoMessage()    {}
 func (*Validator_Simple) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{2, 2}
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 2}
 }
 func (m *Validator_Simple) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator_Simple.Unmarshal(m, b)
@@ -559,6 +1389,13 @@ func (m *Validator_Simple) GetClaims() map[string]string {
 	return nil
 }
 
+func (m *Validator_Simple) GetTypedClaims() map[string]*Value {
+	if m != nil {
+		return m.TypedClaims
+	}
+	return nil
+}
+
 type Validator_Constant struct {
 	Value                bool     `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -570,7 +1407,7 @@ func (m *Validator_Constant) Reset()         { *m = Validator_Constant{} }
 func (m *Validator_Constant) String() string { return proto.CompactTextString(m) }
 func (*Validator_Constant) ProtoMessage()    {}
 func (*Validator_Constant) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{2, 3}
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 3}
 }
 func (m *Validator_Constant) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator_Constant.Unmarshal(m, b)
@@ -597,96 +1434,1701 @@ func (m *Validator_Constant) GetValue() bool {
 	return false
 }
 
-type Evaluator struct {
-	Parser               *Parser    `protobuf:"bytes,1,opt,name=parser,proto3" json:"parser,omitempty"`
-	Validator            *Validator `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+type Validator_Not struct {
+	Validator            *Validator `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *Evaluator) Reset()         { *m = Evaluator{} }
-func (m *Evaluator) String() string { return proto.CompactTextString(m) }
-func (*Evaluator) ProtoMessage()    {}
-func (*Evaluator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_builder_13cb64dd23e78129, []int{3}
+func (m *Validator_Not) Reset()         { *m = Validator_Not{} }
+func (m *Validator_Not) String() string { return proto.CompactTextString(m) }
+func (*Validator_Not) ProtoMessage()    {}
+func (*Validator_Not) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 4}
 }
-func (m *Evaluator) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Evaluator.Unmarshal(m, b)
+func (m *Validator_Not) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Not.Unmarshal(m, b)
 }
-func (m *Evaluator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Evaluator.Marshal(b, m, deterministic)
+func (m *Validator_Not) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Not.Marshal(b, m, deterministic)
 }
-func (dst *Evaluator) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Evaluator.Merge(dst, src)
+func (dst *Validator_Not) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Not.Merge(dst, src)
 }
-func (m *Evaluator) XXX_Size() int {
-	return xxx_messageInfo_Evaluator.Size(m)
+func (m *Validator_Not) XXX_Size() int {
+	return xxx_messageInfo_Validator_Not.Size(m)
 }
-func (m *Evaluator) XXX_DiscardUnknown() {
-	xxx_messageInfo_Evaluator.DiscardUnknown(m)
+func (m *Validator_Not) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Not.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Evaluator proto.InternalMessageInfo
+var xxx_messageInfo_Validator_Not proto.InternalMessageInfo
 
-func (m *Evaluator) GetParser() *Parser {
+func (m *Validator_Not) GetValidator() *Validator {
 	if m != nil {
-		return m.Parser
+		return m.Validator
 	}
 	return nil
 }
 
-func (m *Evaluator) GetValidator() *Validator {
+type Validator_AtLeast struct {
+	N                    uint32       `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Validators           []*Validator `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Validator_AtLeast) Reset()         { *m = Validator_AtLeast{} }
+func (m *Validator_AtLeast) String() string { return proto.CompactTextString(m) }
+func (*Validator_AtLeast) ProtoMessage()    {}
+func (*Validator_AtLeast) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 5}
+}
+func (m *Validator_AtLeast) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_AtLeast.Unmarshal(m, b)
+}
+func (m *Validator_AtLeast) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_AtLeast.Marshal(b, m, deterministic)
+}
+func (dst *Validator_AtLeast) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_AtLeast.Merge(dst, src)
+}
+func (m *Validator_AtLeast) XXX_Size() int {
+	return xxx_messageInfo_Validator_AtLeast.Size(m)
+}
+func (m *Validator_AtLeast) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_AtLeast.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_AtLeast proto.InternalMessageInfo
+
+func (m *Validator_AtLeast) GetN() uint32 {
 	if m != nil {
-		return m.Validator
+		return m.N
+	}
+	return 0
+}
+
+func (m *Validator_AtLeast) GetValidators() []*Validator {
+	if m != nil {
+		return m.Validators
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Parser)(nil), "builder.Parser")
-	proto.RegisterMapType((map[string]string)(nil), "builder.Parser.IssuersEntry")
-	proto.RegisterType((*Shim)(nil), "builder.Shim")
-	proto.RegisterType((*Shim_Elixir)(nil), "builder.Shim.Elixir")
-	proto.RegisterType((*Validator)(nil), "builder.Validator")
-	proto.RegisterType((*Validator_And)(nil), "builder.Validator.And")
+type Validator_Exactly struct {
+	N                    uint32       `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Validators           []*Validator `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Validator_Exactly) Reset()         { *m = Validator_Exactly{} }
+func (m *Validator_Exactly) String() string { return proto.CompactTextString(m) }
+func (*Validator_Exactly) ProtoMessage()    {}
+func (*Validator_Exactly) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 6}
+}
+func (m *Validator_Exactly) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Exactly.Unmarshal(m, b)
+}
+func (m *Validator_Exactly) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Exactly.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Exactly) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Exactly.Merge(dst, src)
+}
+func (m *Validator_Exactly) XXX_Size() int {
+	return xxx_messageInfo_Validator_Exactly.Size(m)
+}
+func (m *Validator_Exactly) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Exactly.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Exactly proto.InternalMessageInfo
+
+func (m *Validator_Exactly) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *Validator_Exactly) GetValidators() []*Validator {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+// Sourced only accepts claims asserted by trusted sources.
+type Validator_Sourced struct {
+	Claims               map[string]*Validator_Sourced_Claim `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *Validator_Sourced) Reset()         { *m = Validator_Sourced{} }
+func (m *Validator_Sourced) String() string { return proto.CompactTextString(m) }
+func (*Validator_Sourced) ProtoMessage()    {}
+func (*Validator_Sourced) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 7}
+}
+func (m *Validator_Sourced) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Sourced.Unmarshal(m, b)
+}
+func (m *Validator_Sourced) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Sourced.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Sourced) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Sourced.Merge(dst, src)
+}
+func (m *Validator_Sourced) XXX_Size() int {
+	return xxx_messageInfo_Validator_Sourced.Size(m)
+}
+func (m *Validator_Sourced) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Sourced.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Sourced proto.InternalMessageInfo
+
+func (m *Validator_Sourced) GetClaims() map[string]*Validator_Sourced_Claim {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+type Validator_Sourced_Claim struct {
+	Value *Value `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Trusted sources.  A source ending in "*" matches any source with the
+	// preceding prefix.
+	Sources              []string `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Validator_Sourced_Claim) Reset()         { *m = Validator_Sourced_Claim{} }
+func (m *Validator_Sourced_Claim) String() string { return proto.CompactTextString(m) }
+func (*Validator_Sourced_Claim) ProtoMessage()    {}
+func (*Validator_Sourced_Claim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 7, 0}
+}
+func (m *Validator_Sourced_Claim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Sourced_Claim.Unmarshal(m, b)
+}
+func (m *Validator_Sourced_Claim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Sourced_Claim.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Sourced_Claim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Sourced_Claim.Merge(dst, src)
+}
+func (m *Validator_Sourced_Claim) XXX_Size() int {
+	return xxx_messageInfo_Validator_Sourced_Claim.Size(m)
+}
+func (m *Validator_Sourced_Claim) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Sourced_Claim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Sourced_Claim proto.InternalMessageInfo
+
+func (m *Validator_Sourced_Claim) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Validator_Sourced_Claim) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+// Timed only accepts claims that were asserted recently enough and do not
+// expire too soon.  Expired claims are never accepted.
+type Validator_Timed struct {
+	Claims               map[string]*Validator_Timed_Claim `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *Validator_Timed) Reset()         { *m = Validator_Timed{} }
+func (m *Validator_Timed) String() string { return proto.CompactTextString(m) }
+func (*Validator_Timed) ProtoMessage()    {}
+func (*Validator_Timed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 8}
+}
+func (m *Validator_Timed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Timed.Unmarshal(m, b)
+}
+func (m *Validator_Timed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Timed.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Timed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Timed.Merge(dst, src)
+}
+func (m *Validator_Timed) XXX_Size() int {
+	return xxx_messageInfo_Validator_Timed.Size(m)
+}
+func (m *Validator_Timed) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Timed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Timed proto.InternalMessageInfo
+
+func (m *Validator_Timed) GetClaims() map[string]*Validator_Timed_Claim {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+type Validator_Timed_Claim struct {
+	// The expected value.  If unset, any value is accepted.
+	Value *Value `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The longest time that may have passed since the claim was asserted,
+	// in the format accepted by time.ParseDuration.
+	MaxAge string `protobuf:"bytes,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// The shortest time that must remain before the claim expires, in the
+	// format accepted by time.ParseDuration.
+	MinRemaining         string   `protobuf:"bytes,3,opt,name=min_remaining,json=minRemaining,proto3" json:"min_remaining,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Validator_Timed_Claim) Reset()         { *m = Validator_Timed_Claim{} }
+func (m *Validator_Timed_Claim) String() string { return proto.CompactTextString(m) }
+func (*Validator_Timed_Claim) ProtoMessage()    {}
+func (*Validator_Timed_Claim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 8, 0}
+}
+func (m *Validator_Timed_Claim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Timed_Claim.Unmarshal(m, b)
+}
+func (m *Validator_Timed_Claim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Timed_Claim.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Timed_Claim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Timed_Claim.Merge(dst, src)
+}
+func (m *Validator_Timed_Claim) XXX_Size() int {
+	return xxx_messageInfo_Validator_Timed_Claim.Size(m)
+}
+func (m *Validator_Timed_Claim) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Timed_Claim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Timed_Claim proto.InternalMessageInfo
+
+func (m *Validator_Timed_Claim) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Validator_Timed_Claim) GetMaxAge() string {
+	if m != nil {
+		return m.MaxAge
+	}
+	return ""
+}
+
+func (m *Validator_Timed_Claim) GetMinRemaining() string {
+	if m != nil {
+		return m.MinRemaining
+	}
+	return ""
+}
+
+// Visa accepts identities with an active passport visa of the given type
+// whose value, source and by fields match those that are set.  These are in
+// the format of visa conditions, for example "const:phs000710" or
+// "pattern:https://doi.org/10.5281/*".
+type Validator_Visa struct {
+	Type   string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value  string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	By     string `protobuf:"bytes,4,opt,name=by,proto3" json:"by,omitempty"`
+	// The longest time that may have passed since the visa was asserted, in
+	// the format accepted by time.ParseDuration.
+	MaxAge string `protobuf:"bytes,5,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// The shortest time that must remain before the visa expires, in the
+	// format accepted by time.ParseDuration.
+	MinRemaining         string   `protobuf:"bytes,6,opt,name=min_remaining,json=minRemaining,proto3" json:"min_remaining,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Validator_Visa) Reset()         { *m = Validator_Visa{} }
+func (m *Validator_Visa) String() string { return proto.CompactTextString(m) }
+func (*Validator_Visa) ProtoMessage()    {}
+func (*Validator_Visa) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 9}
+}
+func (m *Validator_Visa) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Visa.Unmarshal(m, b)
+}
+func (m *Validator_Visa) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Visa.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Visa) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Visa.Merge(dst, src)
+}
+func (m *Validator_Visa) XXX_Size() int {
+	return xxx_messageInfo_Validator_Visa.Size(m)
+}
+func (m *Validator_Visa) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Visa.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Visa proto.InternalMessageInfo
+
+func (m *Validator_Visa) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Validator_Visa) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Validator_Visa) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Validator_Visa) GetBy() string {
+	if m != nil {
+		return m.By
+	}
+	return ""
+}
+
+func (m *Validator_Visa) GetMaxAge() string {
+	if m != nil {
+		return m.MaxAge
+	}
+	return ""
+}
+
+func (m *Validator_Visa) GetMinRemaining() string {
+	if m != nil {
+		return m.MinRemaining
+	}
+	return ""
+}
+
+// Groups accepts identities that are members of any of the groups
+// according to a group membership file.
+type Validator_Groups struct {
+	File                 *GroupFile `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Groups               []string   `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Validator_Groups) Reset()         { *m = Validator_Groups{} }
+func (m *Validator_Groups) String() string { return proto.CompactTextString(m) }
+func (*Validator_Groups) ProtoMessage()    {}
+func (*Validator_Groups) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 10}
+}
+func (m *Validator_Groups) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Groups.Unmarshal(m, b)
+}
+func (m *Validator_Groups) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Groups.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Groups) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Groups.Merge(dst, src)
+}
+func (m *Validator_Groups) XXX_Size() int {
+	return xxx_messageInfo_Validator_Groups.Size(m)
+}
+func (m *Validator_Groups) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Groups.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Groups proto.InternalMessageInfo
+
+func (m *Validator_Groups) GetFile() *GroupFile {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *Validator_Groups) GetGroups() []string {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// Match accepts string claims with a value allowed by the matcher.
+type Validator_Match struct {
+	Claims               map[string]*Matcher `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Validator_Match) Reset()         { *m = Validator_Match{} }
+func (m *Validator_Match) String() string { return proto.CompactTextString(m) }
+func (*Validator_Match) ProtoMessage()    {}
+func (*Validator_Match) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 11}
+}
+func (m *Validator_Match) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Match.Unmarshal(m, b)
+}
+func (m *Validator_Match) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Match.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Match) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Match.Merge(dst, src)
+}
+func (m *Validator_Match) XXX_Size() int {
+	return xxx_messageInfo_Validator_Match.Size(m)
+}
+func (m *Validator_Match) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Match.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Match proto.InternalMessageInfo
+
+func (m *Validator_Match) GetClaims() map[string]*Matcher {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+// Expression accepts identities for which a boolean expression over the
+// identity holds, for example:
+//
+//	has(identity.Role, "researcher") && identity.iss in trusted
+//
+// See validator.Expression for the language.
+type Validator_Expression struct {
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Variables available to the expression.  An any_of value is a list.
+	Variables            map[string]*Value `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Validator_Expression) Reset()         { *m = Validator_Expression{} }
+func (m *Validator_Expression) String() string { return proto.CompactTextString(m) }
+func (*Validator_Expression) ProtoMessage()    {}
+func (*Validator_Expression) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{4, 12}
+}
+func (m *Validator_Expression) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validator_Expression.Unmarshal(m, b)
+}
+func (m *Validator_Expression) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validator_Expression.Marshal(b, m, deterministic)
+}
+func (dst *Validator_Expression) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator_Expression.Merge(dst, src)
+}
+func (m *Validator_Expression) XXX_Size() int {
+	return xxx_messageInfo_Validator_Expression.Size(m)
+}
+func (m *Validator_Expression) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator_Expression.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator_Expression proto.InternalMessageInfo
+
+func (m *Validator_Expression) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Validator_Expression) GetVariables() map[string]*Value {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+// Matcher is a pattern or set of acceptable string claim values.
+type Matcher struct {
+	// Types that are valid to be assigned to Matcher:
+	//	*Matcher_Regexp
+	//	*Matcher_Glob
+	//	*Matcher_Prefix
+	//	*Matcher_Set_
+	Matcher              isMatcher_Matcher `protobuf_oneof:"matcher"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Matcher) Reset()         { *m = Matcher{} }
+func (m *Matcher) String() string { return proto.CompactTextString(m) }
+func (*Matcher) ProtoMessage()    {}
+func (*Matcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{5}
+}
+func (m *Matcher) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Matcher.Unmarshal(m, b)
+}
+func (m *Matcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Matcher.Marshal(b, m, deterministic)
+}
+func (dst *Matcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matcher.Merge(dst, src)
+}
+func (m *Matcher) XXX_Size() int {
+	return xxx_messageInfo_Matcher.Size(m)
+}
+func (m *Matcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Matcher proto.InternalMessageInfo
+
+type isMatcher_Matcher interface {
+	isMatcher_Matcher()
+}
+
+type Matcher_Regexp struct {
+	Regexp string `protobuf:"bytes,1,opt,name=regexp,proto3,oneof"`
+}
+
+type Matcher_Glob struct {
+	Glob string `protobuf:"bytes,2,opt,name=glob,proto3,oneof"`
+}
+
+type Matcher_Prefix struct {
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,proto3,oneof"`
+}
+
+type Matcher_Set_ struct {
+	Set *Matcher_Set `protobuf:"bytes,4,opt,name=set,proto3,oneof"`
+}
+
+func (*Matcher_Regexp) isMatcher_Matcher() {}
+
+func (*Matcher_Glob) isMatcher_Matcher() {}
+
+func (*Matcher_Prefix) isMatcher_Matcher() {}
+
+func (*Matcher_Set_) isMatcher_Matcher() {}
+
+func (m *Matcher) GetMatcher() isMatcher_Matcher {
+	if m != nil {
+		return m.Matcher
+	}
+	return nil
+}
+
+func (m *Matcher) GetRegexp() string {
+	if x, ok := m.GetMatcher().(*Matcher_Regexp); ok {
+		return x.Regexp
+	}
+	return ""
+}
+
+func (m *Matcher) GetGlob() string {
+	if x, ok := m.GetMatcher().(*Matcher_Glob); ok {
+		return x.Glob
+	}
+	return ""
+}
+
+func (m *Matcher) GetPrefix() string {
+	if x, ok := m.GetMatcher().(*Matcher_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (m *Matcher) GetSet() *Matcher_Set {
+	if x, ok := m.GetMatcher().(*Matcher_Set_); ok {
+		return x.Set
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Matcher) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Matcher_OneofMarshaler, _Matcher_OneofUnmarshaler, _Matcher_OneofSizer, []interface{}{
+		(*Matcher_Regexp)(nil),
+		(*Matcher_Glob)(nil),
+		(*Matcher_Prefix)(nil),
+		(*Matcher_Set_)(nil),
+	}
+}
+
+func _Matcher_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Matcher)
+	// matcher
+	switch x := m.Matcher.(type) {
+	case *Matcher_Regexp:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Regexp)
+	case *Matcher_Glob:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Glob)
+	case *Matcher_Prefix:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Prefix)
+	case *Matcher_Set_:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Set); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Matcher.Matcher has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Matcher_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Matcher)
+	switch tag {
+	case 1: // matcher.regexp
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Matcher = &Matcher_Regexp{x}
+		return true, err
+	case 2: // matcher.glob
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Matcher = &Matcher_Glob{x}
+		return true, err
+	case 3: // matcher.prefix
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Matcher = &Matcher_Prefix{x}
+		return true, err
+	case 4: // matcher.set
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Matcher_Set)
+		err := b.DecodeMessage(msg)
+		m.Matcher = &Matcher_Set_{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Matcher_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Matcher)
+	// matcher
+	switch x := m.Matcher.(type) {
+	case *Matcher_Regexp:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.Regexp)))
+		n += len(x.Regexp)
+	case *Matcher_Glob:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.Glob)))
+		n += len(x.Glob)
+	case *Matcher_Prefix:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.Prefix)))
+		n += len(x.Prefix)
+	case *Matcher_Set_:
+		s := proto.Size(x.Set)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type Matcher_Set struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Matcher_Set) Reset()         { *m = Matcher_Set{} }
+func (m *Matcher_Set) String() string { return proto.CompactTextString(m) }
+func (*Matcher_Set) ProtoMessage()    {}
+func (*Matcher_Set) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{5, 0}
+}
+func (m *Matcher_Set) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Matcher_Set.Unmarshal(m, b)
+}
+func (m *Matcher_Set) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Matcher_Set.Marshal(b, m, deterministic)
+}
+func (dst *Matcher_Set) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matcher_Set.Merge(dst, src)
+}
+func (m *Matcher_Set) XXX_Size() int {
+	return xxx_messageInfo_Matcher_Set.Size(m)
+}
+func (m *Matcher_Set) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matcher_Set.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Matcher_Set proto.InternalMessageInfo
+
+func (m *Matcher_Set) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// Value is a typed claim value.  Its type must match the type of the
+// ga4gh.Identity field it is compared with.
+type Value struct {
+	// Types that are valid to be assigned to Value:
+	//	*Value_StringValue
+	//	*Value_BoolValue
+	//	*Value_NumberValue
+	//	*Value_AnyOf_
+	Value                isValue_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Value) Reset()         { *m = Value{} }
+func (m *Value) String() string { return proto.CompactTextString(m) }
+func (*Value) ProtoMessage()    {}
+func (*Value) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{6}
+}
+func (m *Value) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Value.Unmarshal(m, b)
+}
+func (m *Value) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Value.Marshal(b, m, deterministic)
+}
+func (dst *Value) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Value.Merge(dst, src)
+}
+func (m *Value) XXX_Size() int {
+	return xxx_messageInfo_Value.Size(m)
+}
+func (m *Value) XXX_DiscardUnknown() {
+	xxx_messageInfo_Value.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Value proto.InternalMessageInfo
+
+type isValue_Value interface {
+	isValue_Value()
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,3,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type Value_AnyOf_ struct {
+	AnyOf *Value_AnyOf `protobuf:"bytes,4,opt,name=any_of,json=anyOf,proto3,oneof"`
+}
+
+func (*Value_StringValue) isValue_Value() {}
+
+func (*Value_BoolValue) isValue_Value() {}
+
+func (*Value_NumberValue) isValue_Value() {}
+
+func (*Value_AnyOf_) isValue_Value() {}
+
+func (m *Value) GetValue() isValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Value) GetStringValue() string {
+	if x, ok := m.GetValue().(*Value_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *Value) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*Value_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *Value) GetNumberValue() float64 {
+	if x, ok := m.GetValue().(*Value_NumberValue); ok {
+		return x.NumberValue
+	}
+	return 0
+}
+
+func (m *Value) GetAnyOf() *Value_AnyOf {
+	if x, ok := m.GetValue().(*Value_AnyOf_); ok {
+		return x.AnyOf
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Value) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Value_OneofMarshaler, _Value_OneofUnmarshaler, _Value_OneofSizer, []interface{}{
+		(*Value_StringValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_NumberValue)(nil),
+		(*Value_AnyOf_)(nil),
+	}
+}
+
+func _Value_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Value)
+	// value
+	switch x := m.Value.(type) {
+	case *Value_StringValue:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.StringValue)
+	case *Value_BoolValue:
+		t := uint64(0)
+		if x.BoolValue {
+			t = 1
+		}
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(t)
+	case *Value_NumberValue:
+		b.EncodeVarint(3<<3 | proto.WireFixed64)
+		b.EncodeFixed64(math.Float64bits(x.NumberValue))
+	case *Value_AnyOf_:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AnyOf); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Value.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Value_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Value)
+	switch tag {
+	case 1: // value.string_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Value = &Value_StringValue{x}
+		return true, err
+	case 2: // value.bool_value
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Value = &Value_BoolValue{x != 0}
+		return true, err
+	case 3: // value.number_value
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.Value = &Value_NumberValue{math.Float64frombits(x)}
+		return true, err
+	case 4: // value.any_of
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Value_AnyOf)
+		err := b.DecodeMessage(msg)
+		m.Value = &Value_AnyOf_{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Value_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Value)
+	// value
+	switch x := m.Value.(type) {
+	case *Value_StringValue:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.StringValue)))
+		n += len(x.StringValue)
+	case *Value_BoolValue:
+		n += 1 // tag and wire
+		n += 1
+	case *Value_NumberValue:
+		n += 1 // tag and wire
+		n += 8
+	case *Value_AnyOf_:
+		s := proto.Size(x.AnyOf)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type Value_AnyOf struct {
+	Values               []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Value_AnyOf) Reset()         { *m = Value_AnyOf{} }
+func (m *Value_AnyOf) String() string { return proto.CompactTextString(m) }
+func (*Value_AnyOf) ProtoMessage()    {}
+func (*Value_AnyOf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{6, 0}
+}
+func (m *Value_AnyOf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Value_AnyOf.Unmarshal(m, b)
+}
+func (m *Value_AnyOf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Value_AnyOf.Marshal(b, m, deterministic)
+}
+func (dst *Value_AnyOf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Value_AnyOf.Merge(dst, src)
+}
+func (m *Value_AnyOf) XXX_Size() int {
+	return xxx_messageInfo_Value_AnyOf.Size(m)
+}
+func (m *Value_AnyOf) XXX_DiscardUnknown() {
+	xxx_messageInfo_Value_AnyOf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Value_AnyOf proto.InternalMessageInfo
+
+func (m *Value_AnyOf) GetValues() []*Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// GroupFile is a file of group memberships keyed by issuer and subject.  See
+// groups.File for the supported formats.
+type GroupFile struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// How often the file is checked for changes, in the format accepted by
+	// time.ParseDuration.  Defaults to groups.DefaultReloadInterval.
+	ReloadInterval       string   `protobuf:"bytes,2,opt,name=reload_interval,json=reloadInterval,proto3" json:"reload_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupFile) Reset()         { *m = GroupFile{} }
+func (m *GroupFile) String() string { return proto.CompactTextString(m) }
+func (*GroupFile) ProtoMessage()    {}
+func (*GroupFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{7}
+}
+func (m *GroupFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupFile.Unmarshal(m, b)
+}
+func (m *GroupFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupFile.Marshal(b, m, deterministic)
+}
+func (dst *GroupFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupFile.Merge(dst, src)
+}
+func (m *GroupFile) XXX_Size() int {
+	return xxx_messageInfo_GroupFile.Size(m)
+}
+func (m *GroupFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupFile proto.InternalMessageInfo
+
+func (m *GroupFile) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *GroupFile) GetReloadInterval() string {
+	if m != nil {
+		return m.ReloadInterval
+	}
+	return ""
+}
+
+// Enricher adds information held outside of the identity provider to parsed
+// identities.
+type Enricher struct {
+	// Types that are valid to be assigned to Enricher:
+	//	*Enricher_Groups_
+	//	*Enricher_UserInfo_
+	Enricher             isEnricher_Enricher `protobuf_oneof:"enricher"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Enricher) Reset()         { *m = Enricher{} }
+func (m *Enricher) String() string { return proto.CompactTextString(m) }
+func (*Enricher) ProtoMessage()    {}
+func (*Enricher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{8}
+}
+func (m *Enricher) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Enricher.Unmarshal(m, b)
+}
+func (m *Enricher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Enricher.Marshal(b, m, deterministic)
+}
+func (dst *Enricher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Enricher.Merge(dst, src)
+}
+func (m *Enricher) XXX_Size() int {
+	return xxx_messageInfo_Enricher.Size(m)
+}
+func (m *Enricher) XXX_DiscardUnknown() {
+	xxx_messageInfo_Enricher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Enricher proto.InternalMessageInfo
+
+type isEnricher_Enricher interface {
+	isEnricher_Enricher()
+}
+
+type Enricher_Groups_ struct {
+	Groups *Enricher_Groups `protobuf:"bytes,1,opt,name=groups,proto3,oneof"`
+}
+
+type Enricher_UserInfo_ struct {
+	UserInfo *Enricher_UserInfo `protobuf:"bytes,2,opt,name=user_info,json=userInfo,proto3,oneof"`
+}
+
+func (*Enricher_Groups_) isEnricher_Enricher() {}
+
+func (*Enricher_UserInfo_) isEnricher_Enricher() {}
+
+func (m *Enricher) GetEnricher() isEnricher_Enricher {
+	if m != nil {
+		return m.Enricher
+	}
+	return nil
+}
+
+func (m *Enricher) GetGroups() *Enricher_Groups {
+	if x, ok := m.GetEnricher().(*Enricher_Groups_); ok {
+		return x.Groups
+	}
+	return nil
+}
+
+func (m *Enricher) GetUserInfo() *Enricher_UserInfo {
+	if x, ok := m.GetEnricher().(*Enricher_UserInfo_); ok {
+		return x.UserInfo
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Enricher) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Enricher_OneofMarshaler, _Enricher_OneofUnmarshaler, _Enricher_OneofSizer, []interface{}{
+		(*Enricher_Groups_)(nil),
+		(*Enricher_UserInfo_)(nil),
+	}
+}
+
+func _Enricher_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Enricher)
+	// enricher
+	switch x := m.Enricher.(type) {
+	case *Enricher_Groups_:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Groups); err != nil {
+			return err
+		}
+	case *Enricher_UserInfo_:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UserInfo); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Enricher.Enricher has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Enricher_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Enricher)
+	switch tag {
+	case 1: // enricher.groups
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Enricher_Groups)
+		err := b.DecodeMessage(msg)
+		m.Enricher = &Enricher_Groups_{msg}
+		return true, err
+	case 2: // enricher.user_info
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Enricher_UserInfo)
+		err := b.DecodeMessage(msg)
+		m.Enricher = &Enricher_UserInfo_{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Enricher_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Enricher)
+	// enricher
+	switch x := m.Enricher.(type) {
+	case *Enricher_Groups_:
+		s := proto.Size(x.Groups)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Enricher_UserInfo_:
+		s := proto.Size(x.UserInfo)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Groups adds the groups that an identity is a member of according to a
+// group membership file to its Groups claim.
+type Enricher_Groups struct {
+	File *GroupFile `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// The source of the added claims.  Defaults to the path of the file.
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Enricher_Groups) Reset()         { *m = Enricher_Groups{} }
+func (m *Enricher_Groups) String() string { return proto.CompactTextString(m) }
+func (*Enricher_Groups) ProtoMessage()    {}
+func (*Enricher_Groups) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{8, 0}
+}
+func (m *Enricher_Groups) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Enricher_Groups.Unmarshal(m, b)
+}
+func (m *Enricher_Groups) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Enricher_Groups.Marshal(b, m, deterministic)
+}
+func (dst *Enricher_Groups) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Enricher_Groups.Merge(dst, src)
+}
+func (m *Enricher_Groups) XXX_Size() int {
+	return xxx_messageInfo_Enricher_Groups.Size(m)
+}
+func (m *Enricher_Groups) XXX_DiscardUnknown() {
+	xxx_messageInfo_Enricher_Groups.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Enricher_Groups proto.InternalMessageInfo
+
+func (m *Enricher_Groups) GetFile() *GroupFile {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *Enricher_Groups) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+// UserInfo adds the claims returned by the OIDC UserInfo endpoint of an
+// issuer to the identities of that issuer.  See userinfo.Config.
+type Enricher_UserInfo struct {
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// Defaults to the endpoint in the OIDC configuration of the issuer.
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// The timeout of each request, in the format accepted by
+	// time.ParseDuration.  Defaults to userinfo.DefaultTimeout.
+	Timeout string `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// The longest time to cache a response for, in the format accepted by
+	// time.ParseDuration.  Defaults to userinfo.DefaultCacheTTL.
+	CacheTtl string `protobuf:"bytes,4,opt,name=cache_ttl,json=cacheTtl,proto3" json:"cache_ttl,omitempty"`
+	// The most responses to cache.  Defaults to
+	// userinfo.DefaultCacheEntries.
+	CacheEntries         uint32                   `protobuf:"varint,5,opt,name=cache_entries,json=cacheEntries,proto3" json:"cache_entries,omitempty"`
+	Format               Enricher_UserInfo_Format `protobuf:"varint,6,opt,name=format,proto3,enum=builder.Enricher_UserInfo_Format" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *Enricher_UserInfo) Reset()         { *m = Enricher_UserInfo{} }
+func (m *Enricher_UserInfo) String() string { return proto.CompactTextString(m) }
+func (*Enricher_UserInfo) ProtoMessage()    {}
+func (*Enricher_UserInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{8, 1}
+}
+func (m *Enricher_UserInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Enricher_UserInfo.Unmarshal(m, b)
+}
+func (m *Enricher_UserInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Enricher_UserInfo.Marshal(b, m, deterministic)
+}
+func (dst *Enricher_UserInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Enricher_UserInfo.Merge(dst, src)
+}
+func (m *Enricher_UserInfo) XXX_Size() int {
+	return xxx_messageInfo_Enricher_UserInfo.Size(m)
+}
+func (m *Enricher_UserInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Enricher_UserInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Enricher_UserInfo proto.InternalMessageInfo
+
+func (m *Enricher_UserInfo) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *Enricher_UserInfo) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Enricher_UserInfo) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *Enricher_UserInfo) GetCacheTtl() string {
+	if m != nil {
+		return m.CacheTtl
+	}
+	return ""
+}
+
+func (m *Enricher_UserInfo) GetCacheEntries() uint32 {
+	if m != nil {
+		return m.CacheEntries
+	}
+	return 0
+}
+
+func (m *Enricher_UserInfo) GetFormat() Enricher_UserInfo_Format {
+	if m != nil {
+		return m.Format
+	}
+	return Enricher_UserInfo_GA4GH
+}
+
+// Plugin refers to a shim, validator or deny list implementation registered
+// with RegisterShim, RegisterValidator or RegisterDenyList.
+type Plugin struct {
+	// The name the implementation was registered under.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Configuration passed to the registered factory.  Untyped configuration
+	// can be provided by packing a google.protobuf.Struct.
+	Config               *any.Any `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Plugin) Reset()         { *m = Plugin{} }
+func (m *Plugin) String() string { return proto.CompactTextString(m) }
+func (*Plugin) ProtoMessage()    {}
+func (*Plugin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{9}
+}
+func (m *Plugin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Plugin.Unmarshal(m, b)
+}
+func (m *Plugin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Plugin.Marshal(b, m, deterministic)
+}
+func (dst *Plugin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Plugin.Merge(dst, src)
+}
+func (m *Plugin) XXX_Size() int {
+	return xxx_messageInfo_Plugin.Size(m)
+}
+func (m *Plugin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Plugin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Plugin proto.InternalMessageInfo
+
+func (m *Plugin) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Plugin) GetConfig() *any.Any {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type Evaluator struct {
+	Parser    *Parser    `protobuf:"bytes,1,opt,name=parser,proto3" json:"parser,omitempty"`
+	Validator *Validator `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+	// Validators that identities must pass to access individual datasets, keyed
+	// by dataset ID.  A key ending in "*" applies to every dataset with the
+	// preceding prefix that has no more specific entry.  Access to datasets
+	// without an entry is denied.
+	Datasets map[string]*Validator `protobuf:"bytes,3,rep,name=datasets,proto3" json:"datasets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Cache    *Evaluator_Cache      `protobuf:"bytes,4,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Enrichers applied to each parsed identity, in order, before it is
+	// validated.
+	Enrichers            []*Enricher `protobuf:"bytes,5,rep,name=enrichers,proto3" json:"enrichers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Evaluator) Reset()         { *m = Evaluator{} }
+func (m *Evaluator) String() string { return proto.CompactTextString(m) }
+func (*Evaluator) ProtoMessage()    {}
+func (*Evaluator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{10}
+}
+func (m *Evaluator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Evaluator.Unmarshal(m, b)
+}
+func (m *Evaluator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Evaluator.Marshal(b, m, deterministic)
+}
+func (dst *Evaluator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evaluator.Merge(dst, src)
+}
+func (m *Evaluator) XXX_Size() int {
+	return xxx_messageInfo_Evaluator.Size(m)
+}
+func (m *Evaluator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evaluator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evaluator proto.InternalMessageInfo
+
+func (m *Evaluator) GetParser() *Parser {
+	if m != nil {
+		return m.Parser
+	}
+	return nil
+}
+
+func (m *Evaluator) GetValidator() *Validator {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *Evaluator) GetDatasets() map[string]*Validator {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+func (m *Evaluator) GetCache() *Evaluator_Cache {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+func (m *Evaluator) GetEnrichers() []*Enricher {
+	if m != nil {
+		return m.Enrichers
+	}
+	return nil
+}
+
+// Cache configures caching of the identities of successfully evaluated
+// tokens.  See ga4gh.DecisionCache.
+type Evaluator_Cache struct {
+	// The most decisions to hold.  Defaults to ga4gh.DefaultCacheEntries.
+	MaxEntries uint32 `protobuf:"varint,1,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// The longest time to hold a decision for, in the format accepted by
+	// time.ParseDuration.  If unset, only tokens with an expiry are cached.
+	Ttl                  string   `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Evaluator_Cache) Reset()         { *m = Evaluator_Cache{} }
+func (m *Evaluator_Cache) String() string { return proto.CompactTextString(m) }
+func (*Evaluator_Cache) ProtoMessage()    {}
+func (*Evaluator_Cache) Descriptor() ([]byte, []int) {
+	return fileDescriptor_builder_80e69917672a2222, []int{10, 0}
+}
+func (m *Evaluator_Cache) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Evaluator_Cache.Unmarshal(m, b)
+}
+func (m *Evaluator_Cache) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Evaluator_Cache.Marshal(b, m, deterministic)
+}
+func (dst *Evaluator_Cache) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evaluator_Cache.Merge(dst, src)
+}
+func (m *Evaluator_Cache) XXX_Size() int {
+	return xxx_messageInfo_Evaluator_Cache.Size(m)
+}
+func (m *Evaluator_Cache) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evaluator_Cache.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evaluator_Cache proto.InternalMessageInfo
+
+func (m *Evaluator_Cache) GetMaxEntries() uint32 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *Evaluator_Cache) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Parser)(nil), "builder.Parser")
+	proto.RegisterMapType((map[string]*IssuerOptions)(nil), "builder.Parser.IssuerOptionsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "builder.Parser.IssuersEntry")
+	proto.RegisterType((*IssuerOptions)(nil), "builder.IssuerOptions")
+	proto.RegisterType((*DenyList)(nil), "builder.DenyList")
+	proto.RegisterType((*DenyList_File)(nil), "builder.DenyList.File")
+	proto.RegisterType((*Shim)(nil), "builder.Shim")
+	proto.RegisterType((*Shim_Elixir)(nil), "builder.Shim.Elixir")
+	proto.RegisterType((*Shim_Introspection)(nil), "builder.Shim.Introspection")
+	proto.RegisterType((*Validator)(nil), "builder.Validator")
+	proto.RegisterType((*Validator_And)(nil), "builder.Validator.And")
 	proto.RegisterType((*Validator_Or)(nil), "builder.Validator.Or")
 	proto.RegisterType((*Validator_Simple)(nil), "builder.Validator.Simple")
 	proto.RegisterMapType((map[string]string)(nil), "builder.Validator.Simple.ClaimsEntry")
+	proto.RegisterMapType((map[string]*Value)(nil), "builder.Validator.Simple.TypedClaimsEntry")
 	proto.RegisterType((*Validator_Constant)(nil), "builder.Validator.Constant")
+	proto.RegisterType((*Validator_Not)(nil), "builder.Validator.Not")
+	proto.RegisterType((*Validator_AtLeast)(nil), "builder.Validator.AtLeast")
+	proto.RegisterType((*Validator_Exactly)(nil), "builder.Validator.Exactly")
+	proto.RegisterType((*Validator_Sourced)(nil), "builder.Validator.Sourced")
+	proto.RegisterMapType((map[string]*Validator_Sourced_Claim)(nil), "builder.Validator.Sourced.ClaimsEntry")
+	proto.RegisterType((*Validator_Sourced_Claim)(nil), "builder.Validator.Sourced.Claim")
+	proto.RegisterType((*Validator_Timed)(nil), "builder.Validator.Timed")
+	proto.RegisterMapType((map[string]*Validator_Timed_Claim)(nil), "builder.Validator.Timed.ClaimsEntry")
+	proto.RegisterType((*Validator_Timed_Claim)(nil), "builder.Validator.Timed.Claim")
+	proto.RegisterType((*Validator_Visa)(nil), "builder.Validator.Visa")
+	proto.RegisterType((*Validator_Groups)(nil), "builder.Validator.Groups")
+	proto.RegisterType((*Validator_Match)(nil), "builder.Validator.Match")
+	proto.RegisterMapType((map[string]*Matcher)(nil), "builder.Validator.Match.ClaimsEntry")
+	proto.RegisterType((*Validator_Expression)(nil), "builder.Validator.Expression")
+	proto.RegisterMapType((map[string]*Value)(nil), "builder.Validator.Expression.VariablesEntry")
+	proto.RegisterType((*Matcher)(nil), "builder.Matcher")
+	proto.RegisterType((*Matcher_Set)(nil), "builder.Matcher.Set")
+	proto.RegisterType((*Value)(nil), "builder.Value")
+	proto.RegisterType((*Value_AnyOf)(nil), "builder.Value.AnyOf")
+	proto.RegisterType((*GroupFile)(nil), "builder.GroupFile")
+	proto.RegisterType((*Enricher)(nil), "builder.Enricher")
+	proto.RegisterType((*Enricher_Groups)(nil), "builder.Enricher.Groups")
+	proto.RegisterType((*Enricher_UserInfo)(nil), "builder.Enricher.UserInfo")
+	proto.RegisterType((*Plugin)(nil), "builder.Plugin")
 	proto.RegisterType((*Evaluator)(nil), "builder.Evaluator")
-}
-
-func init() { proto.RegisterFile("builder.proto", fileDescriptor_builder_13cb64dd23e78129) }
-
-var fileDescriptor_builder_13cb64dd23e78129 = []byte{
-	// 434 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcf, 0x8b, 0xd3, 0x40,
-	0x14, 0xc7, 0x9b, 0xa4, 0x3b, 0x9b, 0xbc, 0xb8, 0x28, 0xc3, 0x2a, 0x31, 0xeb, 0xa1, 0x44, 0x96,
-	0x5d, 0x3c, 0x04, 0xc9, 0x82, 0x6c, 0x17, 0x3c, 0x6c, 0x4b, 0xa1, 0x3d, 0x55, 0xa6, 0xe0, 0x55,
-	0xd2, 0x66, 0xa4, 0x83, 0x69, 0x52, 0x66, 0xd2, 0x62, 0xaf, 0xfe, 0x27, 0xfe, 0x99, 0xde, 0x64,
-	0x7e, 0x24, 0x8d, 0x35, 0x82, 0x7b, 0xcb, 0xbc, 0xf7, 0xf9, 0xce, 0x7b, 0x6f, 0xde, 0x37, 0x70,
-	0xb1, 0xdc, 0xb1, 0x3c, 0xa3, 0x3c, 0xde, 0xf2, 0xb2, 0x2a, 0xf1, 0xb9, 0x39, 0x46, 0x3f, 0x2d,
-	0x40, 0x9f, 0x52, 0x2e, 0x28, 0xc7, 0x6f, 0xe1, 0x4c, 0xac, 0xd9, 0x46, 0x04, 0xd6, 0xc0, 0xb9,
-	0xf5, 0x93, 0x8b, 0xb8, 0x96, 0x2c, 0xd6, 0x6c, 0x43, 0x74, 0x0e, 0x7f, 0x80, 0x73, 0x26, 0xc4,
-	0x8e, 0x72, 0x11, 0xd8, 0x0a, 0x7b, 0xd3, 0x60, 0xfa, 0x9a, 0x78, 0xa6, 0xd3, 0x93, 0xa2, 0xe2,
-	0x07, 0x52, 0xc3, 0xe1, 0x03, 0x3c, 0x6b, 0x27, 0xf0, 0x0b, 0x70, 0xbe, 0xd1, 0x43, 0x60, 0x0d,
-	0xac, 0x5b, 0x8f, 0xc8, 0x4f, 0x7c, 0x09, 0x67, 0xfb, 0x34, 0xdf, 0xd1, 0xc0, 0x56, 0x31, 0x7d,
-	0x78, 0xb0, 0xef, 0xad, 0x88, 0x42, 0x5f, 0xb6, 0x80, 0x63, 0x40, 0x34, 0x67, 0xdf, 0x19, 0x57,
-	0x32, 0x3f, 0xb9, 0xfc, 0xa3, 0xc3, 0x78, 0xa2, 0x72, 0xd3, 0x1e, 0x31, 0x54, 0x78, 0x0d, 0x48,
-	0xc7, 0xf0, 0x15, 0x78, 0xab, 0x9c, 0xd1, 0xa2, 0xfa, 0xc2, 0x32, 0x53, 0xd3, 0xd5, 0x81, 0x59,
-	0x36, 0x42, 0xd0, 0x97, 0xb3, 0x45, 0xbf, 0x1c, 0xf0, 0x3e, 0xa7, 0x39, 0xcb, 0xd2, 0xaa, 0xe4,
-	0xf8, 0x1d, 0x38, 0x69, 0x91, 0x99, 0x4a, 0xaf, 0x9a, 0x4a, 0x0d, 0x10, 0x3f, 0x16, 0xd9, 0xb4,
-	0x47, 0x24, 0x84, 0x6f, 0xc0, 0x2e, 0xb9, 0xea, 0xdb, 0x4f, 0x5e, 0x76, 0xa0, 0x73, 0xd9, 0x95,
-	0x5d, 0x72, 0x7c, 0x07, 0x48, 0xb0, 0xcd, 0x36, 0xa7, 0x81, 0xa3, 0xe0, 0xd7, 0x1d, 0xf0, 0x42,
-	0x01, 0x72, 0x0c, 0x8d, 0xe2, 0x21, 0xb8, 0xab, 0xb2, 0x10, 0x55, 0x5a, 0x54, 0x41, 0x5f, 0xc9,
-	0xae, 0x3a, 0x64, 0x63, 0x83, 0x4c, 0x7b, 0xa4, 0xc1, 0xc3, 0x21, 0x38, 0x8f, 0x45, 0x86, 0x13,
-	0x80, 0x7d, 0x0d, 0xd6, 0xeb, 0xc5, 0x7f, 0xdf, 0x41, 0x5a, 0x54, 0x78, 0x0f, 0xf6, 0x9c, 0x9f,
-	0x28, 0xed, 0xff, 0x52, 0xfe, 0xb0, 0x00, 0xe9, 0x21, 0xf0, 0x47, 0x40, 0xab, 0x3c, 0x3d, 0x7a,
-	0xea, 0xfa, 0x9f, 0xf3, 0xc6, 0x63, 0xc5, 0x69, 0xd7, 0x18, 0x51, 0x38, 0x04, 0xbf, 0x15, 0x7e,
-	0x8a, 0x67, 0xc2, 0x01, 0xb8, 0xf5, 0x8b, 0x1c, 0x29, 0xa9, 0x74, 0x0d, 0x35, 0xf2, 0xc1, 0x6b,
-	0x9a, 0x8e, 0xbe, 0x82, 0x37, 0x91, 0x61, 0xb5, 0xfa, 0x1b, 0x40, 0x5b, 0xe5, 0x65, 0xb3, 0xfd,
-	0xe7, 0x27, 0x16, 0x27, 0x26, 0x8d, 0xdf, 0xb7, 0xae, 0x30, 0xeb, 0xef, 0x7a, 0x9c, 0x23, 0xb4,
-	0x44, 0xea, 0xf7, 0xbb, 0xfb, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x50, 0x7c, 0xc0, 0x2c, 0x8f, 0x03,
-	0x00, 0x00,
+	proto.RegisterMapType((map[string]*Validator)(nil), "builder.Evaluator.DatasetsEntry")
+	proto.RegisterType((*Evaluator_Cache)(nil), "builder.Evaluator.Cache")
+	proto.RegisterEnum("builder.Enricher_UserInfo_Format", Enricher_UserInfo_Format_name, Enricher_UserInfo_Format_value)
+}
+
+func init() { proto.RegisterFile("builder.proto", fileDescriptor_builder_80e69917672a2222) }
+
+var fileDescriptor_builder_80e69917672a2222 = []byte{
+	// 1820 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x73, 0xdb, 0xc6,
+	0x15, 0x27, 0x00, 0x12, 0x24, 0x1e, 0x45, 0x45, 0xdd, 0xaa, 0x0e, 0x82, 0xc4, 0xb1, 0x4a, 0x27,
+	0xb6, 0x9a, 0x71, 0xe0, 0x8c, 0x92, 0x71, 0x6a, 0x4f, 0xda, 0x8e, 0xac, 0x28, 0xa6, 0x12, 0xc7,
+	0xea, 0x40, 0x8e, 0x27, 0x9d, 0x1e, 0x38, 0x4b, 0x72, 0x49, 0xed, 0x08, 0x5c, 0xb0, 0x8b, 0xa5,
+	0x23, 0x7e, 0x88, 0x5e, 0x72, 0xe8, 0xa9, 0x97, 0x9e, 0x7a, 0xeb, 0xad, 0xd7, 0x9e, 0x7a, 0xe8,
+	0x77, 0xe8, 0x07, 0xe8, 0xb1, 0xb7, 0x9c, 0x3b, 0xfb, 0x07, 0x20, 0x40, 0x82, 0x56, 0x94, 0xf4,
+	0x86, 0x7d, 0xfb, 0x7b, 0x6f, 0xdf, 0xbe, 0xff, 0x0b, 0xe8, 0x0c, 0xe6, 0x34, 0x1e, 0x11, 0x1e,
+	0xce, 0x78, 0x22, 0x12, 0xd4, 0x34, 0xcb, 0xe0, 0x8d, 0x49, 0x92, 0x4c, 0x62, 0x72, 0x5f, 0x91,
+	0x07, 0xf3, 0xf1, 0x7d, 0xcc, 0x16, 0x1a, 0xd3, 0xfd, 0xce, 0x06, 0xf7, 0xb7, 0x98, 0xa7, 0x84,
+	0xa3, 0xdb, 0xd0, 0x48, 0xcf, 0xe9, 0x34, 0xf5, 0xad, 0x3d, 0x67, 0xbf, 0x7d, 0xd0, 0x09, 0x33,
+	0x69, 0x67, 0xe7, 0x74, 0x1a, 0xe9, 0x3d, 0xf4, 0x00, 0x9a, 0x34, 0x4d, 0xe7, 0x84, 0xa7, 0xbe,
+	0xad, 0x60, 0x6f, 0xe5, 0x30, 0x2d, 0x26, 0x3c, 0xd1, 0xdb, 0xc7, 0x4c, 0xf0, 0x45, 0x94, 0x81,
+	0x51, 0x08, 0xde, 0x88, 0xb0, 0x45, 0x3f, 0xa6, 0xa9, 0xf0, 0x9d, 0x3d, 0x6b, 0xbf, 0x7d, 0xf0,
+	0x93, 0x9c, 0xf3, 0x53, 0xc2, 0x16, 0x4f, 0x69, 0x2a, 0xa2, 0xd6, 0xc8, 0x7c, 0xa1, 0x13, 0xd8,
+	0xd6, 0xac, 0xfd, 0x64, 0x26, 0x68, 0xc2, 0x52, 0xbf, 0xae, 0x8e, 0xeb, 0x56, 0x1f, 0x77, 0xaa,
+	0x41, 0xfa, 0xd0, 0x0e, 0x2d, 0xd2, 0x82, 0x47, 0xb0, 0x55, 0xd4, 0x09, 0xed, 0x80, 0x73, 0x41,
+	0x16, 0xbe, 0xb5, 0x67, 0xed, 0x7b, 0x91, 0xfc, 0x44, 0xbb, 0xd0, 0x78, 0x89, 0xe3, 0x39, 0xf1,
+	0x6d, 0x45, 0xd3, 0x8b, 0x47, 0xf6, 0x2f, 0xad, 0xe0, 0x6b, 0x40, 0xeb, 0x07, 0x54, 0x48, 0xb8,
+	0x57, 0x94, 0xd0, 0x3e, 0xb8, 0x91, 0x6b, 0x59, 0xe2, 0x2e, 0x48, 0xee, 0xfe, 0xcd, 0x82, 0x4e,
+	0x69, 0x13, 0xbd, 0x05, 0x1e, 0x9e, 0x8f, 0x28, 0x61, 0x43, 0xa2, 0x7d, 0xe0, 0x45, 0x4b, 0x02,
+	0x7a, 0x1b, 0x00, 0xc7, 0x93, 0x84, 0x53, 0x71, 0x3e, 0xd5, 0xb6, 0xf7, 0xa2, 0x02, 0x05, 0xdd,
+	0x04, 0x18, 0xc6, 0xc9, 0xf0, 0xa2, 0x9f, 0x5e, 0x90, 0x6f, 0x94, 0x85, 0xbd, 0xc8, 0x53, 0x94,
+	0xb3, 0x0b, 0xf2, 0x0d, 0x7a, 0x1d, 0x9a, 0x53, 0x7c, 0xd9, 0xc7, 0x13, 0xe2, 0xd7, 0xd5, 0x9e,
+	0x3b, 0xc5, 0x97, 0x87, 0x13, 0x82, 0xee, 0xc2, 0x6b, 0x9c, 0xfc, 0x61, 0x4e, 0x39, 0x19, 0xf5,
+	0x87, 0x31, 0x96, 0xfe, 0x6f, 0x28, 0xe1, 0xdb, 0x19, 0xf9, 0x48, 0x51, 0xbb, 0x7f, 0xb7, 0xa0,
+	0x95, 0x39, 0x0a, 0xdd, 0x83, 0xfa, 0x98, 0xc6, 0xc4, 0xb7, 0x56, 0xae, 0x9b, 0x01, 0xc2, 0xcf,
+	0x68, 0x4c, 0x7a, 0xb5, 0x48, 0xa1, 0xd0, 0x2f, 0xc0, 0x9d, 0xc5, 0xf3, 0x09, 0x65, 0xc6, 0x3c,
+	0xaf, 0x2d, 0x9d, 0xa8, 0xc8, 0xbd, 0x5a, 0x64, 0x00, 0xc1, 0x11, 0xd4, 0x25, 0x2b, 0x42, 0x50,
+	0x9f, 0x61, 0x71, 0x6e, 0x6c, 0xac, 0xbe, 0xb5, 0xaa, 0x71, 0x82, 0x47, 0x7d, 0xca, 0x04, 0xe1,
+	0x2f, 0x71, 0x6c, 0x1c, 0xb6, 0xad, 0xc9, 0x27, 0x86, 0xfa, 0xb8, 0x5d, 0x08, 0xb6, 0xee, 0x9f,
+	0x1d, 0xa8, 0xcb, 0x08, 0x46, 0x21, 0xb8, 0x24, 0xa6, 0x97, 0x94, 0x1b, 0xad, 0x77, 0x4b, 0x01,
+	0x1e, 0x1e, 0xab, 0x3d, 0xa9, 0x8a, 0x46, 0x5d, 0x43, 0x6b, 0x74, 0x04, 0x1d, 0xca, 0x04, 0x4f,
+	0xd2, 0x19, 0x19, 0x4a, 0x67, 0x9a, 0x08, 0x7f, 0xb3, 0x7c, 0xc2, 0x49, 0x11, 0xd2, 0xab, 0x45,
+	0x65, 0x9e, 0xe0, 0x5d, 0x70, 0xb5, 0x0e, 0xe8, 0x4d, 0xf0, 0x86, 0x31, 0x25, 0x4c, 0xf4, 0xe9,
+	0xc8, 0x58, 0xa0, 0xa5, 0x09, 0x27, 0xa3, 0xe0, 0x9f, 0x32, 0x70, 0x8a, 0x8c, 0x28, 0x80, 0x16,
+	0x61, 0xa3, 0x59, 0x42, 0x99, 0xc8, 0xd0, 0xd9, 0x1a, 0xdd, 0x00, 0x57, 0x67, 0x83, 0x31, 0x95,
+	0x59, 0x95, 0x8f, 0x70, 0xca, 0x47, 0xa0, 0xdb, 0xd0, 0x31, 0x9b, 0x29, 0x19, 0x72, 0x22, 0x4c,
+	0xc8, 0x6c, 0x69, 0xe2, 0x99, 0xa2, 0x21, 0x1f, 0x9a, 0x82, 0x4e, 0x49, 0x32, 0x17, 0x7e, 0x43,
+	0x6d, 0x67, 0x4b, 0xc5, 0x8e, 0x87, 0xe7, 0xa4, 0x4f, 0x98, 0xe0, 0x94, 0xa4, 0xbe, 0xbb, 0x67,
+	0xed, 0x77, 0xa2, 0x2d, 0x45, 0x3c, 0xd6, 0xb4, 0xc7, 0x2e, 0xd4, 0x65, 0x45, 0xe9, 0xfe, 0xe7,
+	0xa7, 0xe0, 0xbd, 0xc0, 0x31, 0x1d, 0x61, 0x91, 0x70, 0xf4, 0x1e, 0x38, 0x98, 0x8d, 0xd6, 0xc2,
+	0x2a, 0x07, 0x84, 0x87, 0x6c, 0xd4, 0xab, 0x45, 0x12, 0x84, 0xee, 0x82, 0x9d, 0x70, 0xe3, 0x9b,
+	0x9f, 0x55, 0x40, 0x4f, 0xa5, 0x33, 0xed, 0x84, 0xa3, 0x0f, 0xc1, 0x4d, 0xe9, 0x74, 0x16, 0x13,
+	0xe3, 0x96, 0x37, 0x2a, 0xc0, 0x67, 0x0a, 0x20, 0x5d, 0xaa, 0xa1, 0xe8, 0x21, 0xb4, 0x86, 0x09,
+	0x4b, 0x05, 0x66, 0xfa, 0xfa, 0x45, 0x6f, 0x2e, 0xd9, 0x8e, 0x0c, 0xa4, 0x57, 0x8b, 0x72, 0x78,
+	0x21, 0x70, 0x1a, 0x57, 0x05, 0xce, 0x03, 0x68, 0xa6, 0xc9, 0x9c, 0x0f, 0xc9, 0x48, 0x19, 0xa9,
+	0x7d, 0x10, 0x54, 0xe9, 0xa6, 0x11, 0xbd, 0x5a, 0x94, 0x81, 0xa5, 0x9d, 0x58, 0x22, 0xfc, 0xe6,
+	0x46, 0x3b, 0x3d, 0x4b, 0xa4, 0x4e, 0x12, 0x84, 0x3e, 0x86, 0x16, 0x16, 0xfd, 0x98, 0xe0, 0x54,
+	0xf8, 0xad, 0x8d, 0x87, 0x1c, 0x8a, 0xa7, 0x12, 0x21, 0x0f, 0xc1, 0xfa, 0x53, 0x2a, 0x47, 0x2e,
+	0xf1, 0x50, 0xc4, 0x0b, 0xdf, 0xdb, 0xc8, 0x77, 0xac, 0x11, 0x92, 0xcf, 0x80, 0xd1, 0x07, 0xd0,
+	0x98, 0x62, 0x31, 0x3c, 0xf7, 0x41, 0x71, 0xf9, 0x15, 0x5c, 0x5f, 0xca, 0xfd, 0x5e, 0x2d, 0xd2,
+	0x40, 0xf4, 0x1b, 0x00, 0x72, 0x39, 0xe3, 0x24, 0x4d, 0x65, 0xf2, 0xb4, 0x15, 0xdb, 0xcd, 0xca,
+	0xc3, 0x32, 0x50, 0xaf, 0x16, 0x15, 0x58, 0xe4, 0x91, 0x32, 0xfa, 0x46, 0xfe, 0xd6, 0xc6, 0x23,
+	0x9f, 0xcb, 0x7d, 0x79, 0xa4, 0x02, 0xa2, 0xf7, 0xa1, 0xfe, 0x92, 0xa6, 0xd8, 0xef, 0x28, 0x86,
+	0xd7, 0x2b, 0x18, 0x5e, 0xd0, 0x14, 0xcb, 0x12, 0x26, 0x61, 0x32, 0x86, 0x26, 0x3c, 0x99, 0xcf,
+	0x52, 0x7f, 0x7b, 0x63, 0x0c, 0x3d, 0x51, 0x00, 0xe9, 0x5d, 0x0d, 0x0d, 0xbe, 0x02, 0xe7, 0x90,
+	0x8d, 0xd0, 0x01, 0xc0, 0xcb, 0x0c, 0x94, 0x75, 0x57, 0xb4, 0xce, 0x1f, 0x15, 0x50, 0x32, 0xa7,
+	0x67, 0x98, 0xe3, 0x38, 0x26, 0xba, 0xc8, 0xb5, 0xa2, 0x7c, 0x1d, 0x3c, 0x07, 0xfb, 0x94, 0xaf,
+	0x48, 0xb5, 0xaf, 0x2d, 0xd5, 0x59, 0x91, 0xfa, 0x17, 0x1b, 0x5c, 0x9d, 0x05, 0xe8, 0x57, 0xe0,
+	0x9a, 0x56, 0xa0, 0x95, 0x7d, 0x77, 0x63, 0xc2, 0x84, 0xba, 0x39, 0xe8, 0xbe, 0x6b, 0x98, 0xd0,
+	0x97, 0xb0, 0x25, 0x16, 0xb3, 0x65, 0x3f, 0xd1, 0xba, 0xbd, 0xb7, 0x59, 0xc8, 0x73, 0x89, 0x2e,
+	0x4a, 0x6a, 0x8b, 0x25, 0x25, 0x78, 0x08, 0xed, 0xc2, 0xde, 0xb5, 0xda, 0xf7, 0x33, 0xd8, 0x59,
+	0x95, 0x5d, 0xc1, 0xff, 0x4e, 0xb9, 0x79, 0x6f, 0x17, 0x15, 0x9d, 0x93, 0xa2, 0xbc, 0x3d, 0x68,
+	0x65, 0x19, 0xbf, 0x3c, 0xd5, 0x52, 0x86, 0xd4, 0x8b, 0xe0, 0x63, 0x70, 0x9e, 0x25, 0x02, 0x7d,
+	0x00, 0x5e, 0x6e, 0x76, 0x53, 0xcd, 0xaa, 0x7c, 0xb3, 0x04, 0x05, 0x5f, 0x40, 0xd3, 0xa4, 0x20,
+	0xda, 0x02, 0x8b, 0x29, 0xa6, 0x4e, 0x64, 0xb1, 0x1f, 0xe2, 0x67, 0x29, 0xcc, 0xe4, 0xe5, 0xff,
+	0x41, 0xd8, 0x7f, 0x2d, 0x68, 0x9a, 0x12, 0x84, 0x7e, 0xbd, 0x12, 0x19, 0x77, 0x36, 0x97, 0xab,
+	0xaa, 0xd0, 0x08, 0x9e, 0x40, 0x43, 0x91, 0x97, 0x36, 0xb7, 0x5e, 0x61, 0x73, 0xd9, 0x63, 0x74,
+	0xc5, 0xcb, 0x26, 0x9e, 0x6c, 0x19, 0xfc, 0xfe, 0xaa, 0xa0, 0x78, 0x50, 0x76, 0xea, 0xde, 0x55,
+	0x8a, 0x16, 0xdd, 0xfc, 0x47, 0x1b, 0x1a, 0xaa, 0x5c, 0xa0, 0x4f, 0x56, 0xee, 0xfb, 0xce, 0xa6,
+	0xc2, 0x52, 0x79, 0xdb, 0x8b, 0xeb, 0xdd, 0xb6, 0x30, 0xa3, 0xd9, 0xa5, 0x19, 0xed, 0x36, 0x74,
+	0xa6, 0x94, 0xf5, 0x39, 0x99, 0x62, 0xca, 0x28, 0x9b, 0x98, 0x86, 0xbd, 0x35, 0xa5, 0x2c, 0xca,
+	0x68, 0xc1, 0xef, 0xae, 0xb2, 0xc8, 0x47, 0x65, 0x8b, 0xbc, 0xfd, 0xea, 0xab, 0x14, 0xed, 0xf1,
+	0x27, 0x0b, 0xea, 0xb2, 0x1a, 0xca, 0xa9, 0x4c, 0x66, 0x66, 0x36, 0x95, 0xc9, 0xef, 0xea, 0xec,
+	0x93, 0x73, 0x87, 0x76, 0x95, 0xd1, 0xd5, 0xac, 0xd0, 0x36, 0xd8, 0x83, 0x85, 0x99, 0x27, 0xec,
+	0xc1, 0xa2, 0x78, 0xe7, 0xc6, 0xab, 0xef, 0xec, 0x56, 0xdc, 0xb9, 0x07, 0xae, 0x2e, 0xba, 0xe8,
+	0x4e, 0x69, 0x20, 0x5d, 0x86, 0xb4, 0xda, 0x96, 0x13, 0xa5, 0x19, 0x45, 0x6f, 0xe4, 0x75, 0x5c,
+	0x07, 0x94, 0x59, 0x05, 0xdf, 0x5a, 0xd0, 0x50, 0x4d, 0xe9, 0x7b, 0xb9, 0x5c, 0x21, 0x2b, 0x5d,
+	0xfe, 0xc5, 0x55, 0x5e, 0xb8, 0x53, 0xf6, 0xc2, 0x4e, 0x2e, 0x5d, 0xc9, 0x24, 0xbc, 0x68, 0xf7,
+	0x7f, 0x58, 0x00, 0xcb, 0x96, 0x57, 0xb0, 0xa9, 0x55, 0xb2, 0xe9, 0xe7, 0xb2, 0xd8, 0x70, 0x8a,
+	0x07, 0x31, 0xc9, 0x72, 0xfa, 0xde, 0x2b, 0x9b, 0x67, 0xf8, 0x22, 0x83, 0x6b, 0xe5, 0x97, 0xec,
+	0xc1, 0x53, 0xd8, 0x2e, 0x6f, 0xfe, 0x98, 0x7a, 0x29, 0x07, 0xf1, 0xbc, 0x90, 0x74, 0xff, 0x6a,
+	0x41, 0xd3, 0x5c, 0x12, 0xf9, 0xe0, 0x72, 0x32, 0x21, 0x97, 0x33, 0x2d, 0x57, 0xf6, 0x4c, 0xbd,
+	0x46, 0xbb, 0x50, 0x9f, 0xc4, 0xc9, 0x40, 0x47, 0x93, 0x6c, 0xbf, 0x72, 0x25, 0xf1, 0x33, 0x4e,
+	0xc6, 0xf4, 0xd2, 0x77, 0x32, 0xbc, 0x5e, 0xa3, 0x7d, 0x70, 0x52, 0x92, 0x8d, 0x68, 0xbb, 0xab,
+	0xd6, 0x0c, 0xcf, 0x88, 0x9a, 0x83, 0x52, 0x22, 0x82, 0x9b, 0xe0, 0x9c, 0x11, 0x35, 0x11, 0x2b,
+	0x05, 0xb3, 0x37, 0x96, 0x59, 0x3d, 0xf6, 0x64, 0x24, 0x2a, 0xa6, 0xee, 0xbf, 0x2d, 0x68, 0xa8,
+	0xbb, 0xa0, 0xdb, 0xb0, 0x95, 0x0a, 0x4e, 0xd9, 0xa4, 0xbf, 0xcc, 0x5f, 0x79, 0x7a, 0x5b, 0x53,
+	0x35, 0xe8, 0x16, 0xc0, 0x20, 0x49, 0xe2, 0xfe, 0xd2, 0x28, 0xad, 0x5e, 0x2d, 0xf2, 0x24, 0x2d,
+	0x97, 0xc2, 0xe6, 0xd3, 0x01, 0xe1, 0x06, 0x22, 0xef, 0x60, 0x49, 0x29, 0x9a, 0xaa, 0x41, 0xef,
+	0x83, 0x8b, 0xd9, 0xa2, 0x9f, 0x8c, 0xd7, 0xee, 0xa2, 0xf6, 0xc3, 0x43, 0xb6, 0x38, 0x1d, 0xcb,
+	0xf9, 0x05, 0xcb, 0x8f, 0xe0, 0x3e, 0x34, 0x14, 0x05, 0xdd, 0x29, 0xdd, 0x67, 0xdd, 0x1d, 0xd9,
+	0xfd, 0x9a, 0xc6, 0x6b, 0xdd, 0x1e, 0x78, 0x79, 0x56, 0xfc, 0xa8, 0x77, 0x56, 0xf7, 0x5f, 0x0e,
+	0xb4, 0x8e, 0x19, 0xa7, 0xca, 0xa5, 0x07, 0x79, 0x66, 0x59, 0x2b, 0x33, 0x58, 0x06, 0x59, 0x1b,
+	0x90, 0xd0, 0x43, 0xf0, 0xe6, 0x29, 0xe1, 0x7d, 0xca, 0xc6, 0x89, 0x6f, 0xaf, 0xcc, 0x98, 0x39,
+	0xdb, 0x57, 0x29, 0xe1, 0x27, 0x6c, 0x9c, 0xc8, 0x21, 0x7b, 0x6e, 0xbe, 0x7f, 0x58, 0xea, 0x9b,
+	0xf4, 0xb1, 0x8b, 0xe9, 0x13, 0x7c, 0x67, 0x41, 0x2b, 0x3b, 0xa2, 0xf0, 0x5e, 0xb2, 0x4a, 0xef,
+	0xa5, 0xe2, 0x1b, 0xcb, 0x5e, 0x79, 0x63, 0x15, 0x5e, 0x42, 0x4e, 0xf9, 0x25, 0x24, 0x5f, 0x59,
+	0xea, 0x25, 0x24, 0x44, 0x6c, 0x8a, 0x5e, 0x4b, 0x11, 0x9e, 0x8b, 0x78, 0xfd, 0x99, 0xd4, 0x58,
+	0x7f, 0x26, 0xa1, 0x87, 0xe0, 0x8e, 0x13, 0x3e, 0xc5, 0x42, 0xd5, 0xbf, 0xed, 0x83, 0x9f, 0x6f,
+	0x36, 0x4f, 0xf8, 0x99, 0x02, 0x46, 0x86, 0xa1, 0x7b, 0x0b, 0x5c, 0x4d, 0x41, 0x1e, 0x34, 0x9e,
+	0x1c, 0x7e, 0xf4, 0xa4, 0xb7, 0x53, 0x43, 0x00, 0xee, 0xf1, 0xd3, 0x93, 0xaf, 0x4f, 0xa2, 0x1d,
+	0xeb, 0x31, 0xc8, 0x3b, 0x69, 0x21, 0xdd, 0xcf, 0xc1, 0xd5, 0x8f, 0x13, 0x19, 0x11, 0x0c, 0x4f,
+	0xf3, 0x1a, 0x2f, 0xbf, 0xd1, 0x3d, 0x70, 0x87, 0x09, 0x1b, 0xd3, 0x89, 0x71, 0xd2, 0x6e, 0xa8,
+	0xff, 0x28, 0x85, 0xd9, 0x1f, 0x25, 0x19, 0x9d, 0x91, 0xc1, 0x74, 0xbf, 0x75, 0xc0, 0x3b, 0x96,
+	0xb1, 0xa6, 0x9e, 0x74, 0x77, 0xc1, 0x9d, 0xa9, 0x5f, 0x35, 0xbe, 0xb5, 0xfa, 0x1a, 0x52, 0xe4,
+	0xc8, 0x6c, 0x97, 0x67, 0x26, 0xfb, 0x7b, 0xcc, 0x4c, 0xe8, 0x13, 0x68, 0x8d, 0xb0, 0xc0, 0x29,
+	0x11, 0xa9, 0xef, 0xec, 0x39, 0xa5, 0x36, 0x9f, 0x2b, 0x10, 0x7e, 0x6a, 0x20, 0xba, 0xd6, 0xe5,
+	0x1c, 0x28, 0x84, 0x86, 0x32, 0xb5, 0x5f, 0x5f, 0x8d, 0xd7, 0x9c, 0xf5, 0x48, 0xee, 0x47, 0x1a,
+	0x86, 0xee, 0x83, 0x97, 0x99, 0x4b, 0xff, 0x23, 0x29, 0xfe, 0xc2, 0xca, 0xbc, 0x11, 0x2d, 0x31,
+	0xc1, 0x23, 0x68, 0x28, 0x01, 0xe8, 0x16, 0xb4, 0x65, 0x93, 0xcb, 0xfc, 0xac, 0xa7, 0x31, 0x98,
+	0xe2, 0xcb, 0xcc, 0xcb, 0x3b, 0xe0, 0xc8, 0x08, 0xd1, 0x81, 0x25, 0x3f, 0x83, 0x53, 0xe8, 0x94,
+	0xf4, 0xae, 0x28, 0xc3, 0xfb, 0xe5, 0x32, 0x5c, 0x65, 0xab, 0x65, 0x29, 0x1e, 0xb8, 0xca, 0x55,
+	0x1f, 0xfe, 0x6f, 0x00, 0xd4, 0x75, 0xde, 0xe1, 0x24, 0x14, 0x00, 0x00,
 }
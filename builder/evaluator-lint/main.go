@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The evaluator-lint command checks builder.Evaluator configurations for
+// mistakes before they are deployed.  Each argument is a text proto, JSON or
+// YAML file, as indicated by its extension.  It prints one line per issue
+// found and exits with a non-zero status if any of them were errors rather
+// than warnings.
+//
+// Usage:
+//
+//	evaluator-lint evaluator.textproto [more files...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/googlegenomics/ga4gh-identity/builder"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s FILE...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, path := range flag.Args() {
+		e, err := builder.Load(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		for _, issue := range builder.Lint(e) {
+			fmt.Printf("%s: %v\n", path, issue)
+			if issue.Severity == builder.Error {
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Format is an encoding of a configuration message.
+type Format int
+
+const (
+	// Text is the protocol buffer text format.
+	Text Format = iota
+	// JSON is the protocol buffer JSON mapping.
+	JSON
+	// YAML is the protocol buffer JSON mapping written as YAML.
+	YAML
+)
+
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case JSON:
+		return "JSON"
+	case YAML:
+		return "YAML"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// FormatFromPath returns the Format implied by the extension of path.  Text
+// protos use ".textproto", ".textpb", ".pbtxt" or ".txt", JSON uses ".json"
+// and YAML uses ".yaml" or ".yml".
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".textproto", ".textpb", ".pbtxt", ".txt":
+		return Text, nil
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	}
+	return 0, fmt.Errorf("unknown configuration format for %q", path)
+}
+
+// Load reads an Evaluator from path, using the format implied by its
+// extension.
+func Load(path string) (*Evaluator, error) {
+	var e Evaluator
+	if err := LoadMessage(path, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// LoadMessage reads any configuration message from path, using the format
+// implied by its extension.
+func LoadMessage(path string, m proto.Message) error {
+	f, err := FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %q: %v", path, err)
+	}
+	if err := Unmarshal(b, f, m); err != nil {
+		return fmt.Errorf("parsing %q: %v", path, err)
+	}
+	return nil
+}
+
+// Unmarshal parses data in the given format into m.  Where possible, errors
+// identify the line of data that caused them.
+func Unmarshal(data []byte, f Format, m proto.Message) error {
+	switch f {
+	case Text:
+		// Text format errors already carry line numbers.
+		return proto.UnmarshalText(string(data), m)
+
+	case JSON:
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			if serr, ok := err.(*json.SyntaxError); ok {
+				return fmt.Errorf("line %d: %v", lineOf(data, serr.Offset), err)
+			}
+			return err
+		}
+		return unmarshalJSON(data, data, m)
+
+	case YAML:
+		var v interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		js, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("converting YAML to JSON: %v", err)
+		}
+		return unmarshalJSON(js, data, m)
+	}
+	return fmt.Errorf("unsupported format %v", f)
+}
+
+// Marshal encodes m in the given format.
+func Marshal(m proto.Message, f Format) ([]byte, error) {
+	switch f {
+	case Text:
+		return []byte(proto.MarshalTextString(m)), nil
+
+	case JSON:
+		s, err := (&jsonpb.Marshaler{OrigName: true, Indent: "  "}).MarshalToString(m)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s + "\n"), nil
+
+	case YAML:
+		s, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(m)
+		if err != nil {
+			return nil, err
+		}
+		// Decoding into a yaml.Node preserves the field order chosen by jsonpb.
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(s), &node); err != nil {
+			return nil, err
+		}
+		clearStyle(&node)
+		return yaml.Marshal(&node)
+	}
+	return nil, fmt.Errorf("unsupported format %v", f)
+}
+
+// clearStyle removes the flow style that JSON input leaves on node so that it
+// is written as block-style YAML.
+func clearStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	for _, n := range node.Content {
+		clearStyle(n)
+	}
+}
+
+var unknownField = regexp.MustCompile(`^unknown field "([^"]+)"`)
+
+// unmarshalJSON parses the JSON in js into m.  If m rejects a field then the
+// error is annotated with the line of the field in source, which is either
+// the same JSON or the YAML it was converted from.
+func unmarshalJSON(js, source []byte, m proto.Message) error {
+	err := jsonpb.Unmarshal(bytes.NewReader(js), m)
+	if err == nil {
+		return nil
+	}
+	if match := unknownField.FindStringSubmatch(err.Error()); match != nil {
+		var node yaml.Node
+		if yaml.Unmarshal(source, &node) == nil {
+			if line := keyLine(&node, match[1]); line > 0 {
+				return fmt.Errorf("line %d: %v", line, err)
+			}
+		}
+	}
+	return err
+}
+
+// keyLine returns the line of the first mapping key named key in node, or 0 if
+// there is none.
+func keyLine(node *yaml.Node, key string) int {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i].Line
+			}
+		}
+	}
+	for _, n := range node.Content {
+		if line := keyLine(n, key); line > 0 {
+			return line
+		}
+	}
+	return 0
+}
+
+// lineOf returns the 1-based line number containing offset in data.
+func lineOf(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
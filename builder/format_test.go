@@ -0,0 +1,151 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const testEvaluator = `
+parser {
+  shims { elixir { client_id: "client" } }
+  issuers { key: "https://idp.example.com" value: "other-client" }
+}
+validator {
+  and {
+    validators { simple { claims { key: "Role" value: "researcher" } } }
+    validators { constant { value: true } }
+  }
+}
+`
+
+func TestFormatRoundTrip(t *testing.T) {
+	var want Evaluator
+	if err := proto.UnmarshalText(testEvaluator, &want); err != nil {
+		t.Fatalf("Error parsing evaluator: %v", err)
+	}
+	for _, f := range []Format{Text, JSON, YAML} {
+		t.Run(f.String(), func(t *testing.T) {
+			b, err := Marshal(&want, f)
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+			var got Evaluator
+			if err := Unmarshal(b, f, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) = %v", b, err)
+			}
+			if !proto.Equal(&got, &want) {
+				t.Fatalf("Round trip through %v = %v, want = %v", f, &got, &want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const in = `
+parser:
+  shims:
+    - elixir:
+        client_id: client
+validator:
+  or:
+    validators:
+      - simple:
+          claims:
+            Role: researcher
+      - constant:
+          value: true
+`
+	var e Evaluator
+	if err := Unmarshal([]byte(in), YAML, &e); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if got := e.Parser.Shims[0].GetElixir().GetClientId(); got != "client" {
+		t.Errorf("client_id = %q, want = %q", got, "client")
+	}
+	if got := len(e.Validator.GetOr().GetValidators()); got != 2 {
+		t.Errorf("len(or.validators) = %d, want = 2", got)
+	}
+}
+
+func TestUnmarshalErrorLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		in     string
+		line   string
+	}{
+		{
+			name:   "text unknown field",
+			format: Text,
+			in:     "validator {\n  constant {\n    valu: true\n  }\n}\n",
+			line:   "line 3",
+		},
+		{
+			name:   "JSON syntax",
+			format: JSON,
+			in:     "{\n  \"validator\": {\n    \"constant\": {,\n  }\n}\n",
+			line:   "line 3",
+		},
+		{
+			name:   "JSON unknown field",
+			format: JSON,
+			in:     "{\n  \"validator\": {\n    \"constant\": {\n      \"valu\": true\n    }\n  }\n}\n",
+			line:   "line 4",
+		},
+		{
+			name:   "YAML syntax",
+			format: YAML,
+			in:     "validator:\n  constant:\n\tvalue: true\n",
+			line:   "line 3",
+		},
+		{
+			name:   "YAML unknown field",
+			format: YAML,
+			in:     "validator:\n  constant:\n    valu: true\n",
+			line:   "line 3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var e Evaluator
+			err := Unmarshal([]byte(test.in), test.format, &e)
+			if err == nil {
+				t.Fatal("Unmarshal() succeeded, want error")
+			}
+			if !strings.Contains(err.Error(), test.line) {
+				t.Fatalf("Unmarshal() = %v, want error mentioning %q", err, test.line)
+			}
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	for path, want := range map[string]Format{
+		"evaluator.textproto": Text,
+		"evaluator.JSON":      JSON,
+		"dir/evaluator.yml":   YAML,
+	} {
+		if got, err := FormatFromPath(path); err != nil || got != want {
+			t.Errorf("FormatFromPath(%q) = (%v, %v), want = %v", path, got, err, want)
+		}
+	}
+	if _, err := FormatFromPath("evaluator.cfg"); err == nil {
+		t.Error("FormatFromPath(\"evaluator.cfg\") succeeded, want error")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Source provides the current version of an Evaluator configuration.
+type Source interface {
+	// Fetch returns the current configuration along with a version that
+	// changes whenever the configuration does.
+	Fetch(ctx context.Context) (*Evaluator, string, error)
+}
+
+// FileSource is a Source that reads a configuration from a file in any of the
+// formats supported by Load.  Its version is derived from the file contents.
+type FileSource struct {
+	Path string
+}
+
+// Fetch implements the Source interface.
+func (s *FileSource) Fetch(context.Context) (*Evaluator, string, error) {
+	f, err := FormatFromPath(s.Path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %q: %v", s.Path, err)
+	}
+	var e Evaluator
+	if err := Unmarshal(b, f, &e); err != nil {
+		return nil, "", fmt.Errorf("parsing %q: %v", s.Path, err)
+	}
+	sum := sha256.Sum256(b)
+	return &e, hex.EncodeToString(sum[:])[:12], nil
+}
+
+// HolderOptions is used with NewHolder to configure a new Holder.
+type HolderOptions struct {
+	// Check is applied to each configuration before it is built.  If it returns
+	// an error the configuration is rejected.  If nil, configurations are
+	// rejected if Lint reports any issues of severity Error.
+	Check func(*Evaluator) error
+	// OnReload, if set, is called by Watch whenever a reload changes the active
+	// configuration or fails.  It is passed the version that is active
+	// afterwards and the error, if any, that prevented a new configuration
+	// from being used.
+	OnReload func(version string, err error)
+}
+
+type active struct {
+	evaluator *ga4gh.Evaluator
+	version   string
+}
+
+// Holder is a ga4gh.IdentityEvaluator whose configuration can be replaced
+// while it is in use.  A new configuration only replaces the active one if it
+// passes the configured check and builds successfully; otherwise the previous
+// configuration remains active.
+type Holder struct {
+	source  Source
+	opts    HolderOptions
+	current atomic.Value // *active
+	mu      sync.Mutex   // serializes reloads
+}
+
+// NewHolder creates a new Holder from the configuration currently provided by
+// source, which must pass the check and build successfully.
+func NewHolder(ctx context.Context, source Source, opts *HolderOptions) (*Holder, error) {
+	h := &Holder{source: source}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Check == nil {
+		h.opts.Check = CheckLint
+	}
+	if _, err := h.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// CheckLint returns an error listing the issues of severity Error that Lint
+// reports for e.  Warnings are ignored.  It is the default HolderOptions.Check.
+func CheckLint(e *Evaluator) error {
+	var msgs []string
+	for _, issue := range Lint(e) {
+		if issue.Severity == Error {
+			msgs = append(msgs, issue.String())
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New("lint issues: " + strings.Join(msgs, "; "))
+}
+
+// Evaluate implements the ga4gh.IdentityEvaluator interface using the active
+// configuration.
+func (h *Holder) Evaluate(ctx context.Context, auth string) (*ga4gh.Identity, error) {
+	return h.Evaluator().Evaluate(ctx, auth)
+}
+
+// Authorize implements the ga4gh.Authorizer interface using the active
+// configuration.
+func (h *Holder) Authorize(ctx context.Context, identity *ga4gh.Identity, resource string) (bool, error) {
+	return h.Evaluator().Authorize(ctx, identity, resource)
+}
+
+// Evaluator returns the active evaluator.
+func (h *Holder) Evaluator() *ga4gh.Evaluator {
+	return h.current.Load().(*active).evaluator
+}
+
+// Version returns the version of the active configuration.
+func (h *Holder) Version() string {
+	return h.current.Load().(*active).version
+}
+
+// Reload fetches the configuration from the source and, if its version differs
+// from the active one, checks and builds it and makes it active.  It reports
+// whether the active configuration changed.
+func (h *Holder) Reload(ctx context.Context) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	e, version, err := h.source.Fetch(ctx)
+	if err != nil {
+		return false, fmt.Errorf("fetching configuration: %v", err)
+	}
+	if cur, ok := h.current.Load().(*active); ok && cur.version == version {
+		return false, nil
+	}
+	if err := h.opts.Check(e); err != nil {
+		return false, fmt.Errorf("checking configuration %s: %v", version, err)
+	}
+	ev, err := Build(ctx, e)
+	if err != nil {
+		return false, fmt.Errorf("building configuration %s: %v", version, err)
+	}
+	h.current.Store(&active{evaluator: ev, version: version})
+	return true, nil
+}
+
+// Watch calls Reload every interval until ctx is done.
+func (h *Holder) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := h.Reload(ctx)
+			if h.opts.OnReload != nil && (changed || err != nil) {
+				h.opts.OnReload(h.Version(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHolder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "holder")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "evaluator.textproto")
+	write := func(config string) {
+		if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatalf("Error writing configuration: %v", err)
+		}
+	}
+
+	const (
+		human = `parser { shims { plugin { name: "test-static" } } } validator { simple { claims { key: "Role" value: "human" } } }`
+		robot = `parser { shims { plugin { name: "test-static" } } } validator { simple { claims { key: "Role" value: "robot" } } }`
+		lints = `parser { shims { plugin { name: "test-static" } } } validator { simple { claims { key: "Rol" value: "robot" } } }`
+		warns = `parser { shims { plugin { name: "test-static" } } } validator { constant { value: true } }`
+	)
+
+	ctx := context.Background()
+	write(human)
+	h, err := NewHolder(ctx, &FileSource{Path: path}, nil)
+	if err != nil {
+		t.Fatalf("NewHolder() = %v", err)
+	}
+	if _, err := h.Evaluate(ctx, "token"); err != nil {
+		t.Fatalf("Evaluate() = %v, want success", err)
+	}
+	initial := h.Version()
+
+	if changed, err := h.Reload(ctx); changed || err != nil {
+		t.Fatalf("Reload() of unchanged file = (%v, %v), want (false, nil)", changed, err)
+	}
+
+	write(lints)
+	if changed, err := h.Reload(ctx); changed || err == nil {
+		t.Fatalf("Reload() of unclean file = (%v, %v), want (false, error)", changed, err)
+	}
+	if h.Version() != initial {
+		t.Fatalf("Version() = %q after rejected reload, want %q", h.Version(), initial)
+	}
+
+	write(robot)
+	if changed, err := h.Reload(ctx); !changed || err != nil {
+		t.Fatalf("Reload() = (%v, %v), want (true, nil)", changed, err)
+	}
+	if h.Version() == initial {
+		t.Fatal("Version() did not change after reload")
+	}
+	if _, err := h.Evaluate(ctx, "token"); err == nil {
+		t.Fatal("Evaluate() succeeded with the reloaded configuration, want failure")
+	}
+
+	// Lint warnings do not block a reload.
+	write(warns)
+	if changed, err := h.Reload(ctx); !changed || err != nil {
+		t.Fatalf("Reload() of a file with lint warnings = (%v, %v), want (true, nil)", changed, err)
+	}
+}
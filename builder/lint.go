@@ -0,0 +1,550 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	oidc "github.com/coreos/go-oidc"
+	"github.com/golang/protobuf/proto"
+	"github.com/googlegenomics/ga4gh-identity/shim/elixir"
+	"github.com/googlegenomics/ga4gh-identity/validator"
+)
+
+// Severity classifies the issues found by Lint.
+type Severity int
+
+const (
+	// Error is the severity of mistakes that cause errors or wrong decisions
+	// at request time.
+	Error Severity = iota
+	// Warning is the severity of valid configurations that are likely to be
+	// unintended, such as a validator that accepts every identity.
+	Warning
+)
+
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Issue is a problem found in a configuration by Lint.
+type Issue struct {
+	// Path locates the problem within the configuration, for example
+	// "validator.and.validators[1]".
+	Path string
+	// Message describes the problem.
+	Message string
+	// Severity is the importance of the problem.
+	Severity Severity
+}
+
+func (i Issue) String() string {
+	if i.Severity == Warning {
+		return i.Path + ": warning: " + i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Lint statically checks e for mistakes that Build does not detect but that
+// would cause errors or unexpected decisions at request time.  It does not
+// contact any of the configured issuers.
+func Lint(e *Evaluator) []Issue {
+	var l linter
+	l.parser("parser", e.GetParser())
+	switch l.validator("validator", e.GetValidator()) {
+	case alwaysTrue:
+		l.warn("validator", "accepts every identity")
+	case alwaysFalse:
+		l.warn("validator", "rejects every identity")
+	}
+	for i, en := range e.GetEnrichers() {
+		enPath := fmt.Sprintf("enrichers[%d]", i)
+		switch en := en.GetEnricher().(type) {
+		case *Enricher_Groups_:
+			l.groupFile(enPath+".groups.file", en.Groups.File)
+		case *Enricher_UserInfo_:
+			l.userInfo(enPath+".user_info", en.UserInfo)
+		default:
+			l.report(enPath, "no enricher set")
+		}
+	}
+	if c := e.GetCache(); c != nil {
+		if _, err := buildCache(c); err != nil {
+			l.report("cache", "%v", err)
+		}
+	}
+	var datasets []string
+	for id := range e.GetDatasets() {
+		datasets = append(datasets, id)
+	}
+	sort.Strings(datasets)
+	for _, id := range datasets {
+		// A dataset that is constantly denied is a legitimate way of
+		// excluding it from a broader prefix, so only nested issues are
+		// reported.
+		l.validator(fmt.Sprintf("datasets[%q]", id), e.Datasets[id])
+	}
+	return l.issues
+}
+
+type linter struct {
+	issues []Issue
+}
+
+// report records an issue of severity Error.
+func (l *linter) report(path, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// warn records an issue of severity Warning.
+func (l *linter) warn(path, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...), Severity: Warning})
+}
+
+func (l *linter) parser(path string, p *Parser) {
+	if p == nil {
+		l.report(path, "missing parser")
+		return
+	}
+	if len(p.Shims) == 0 && len(p.Issuers) == 0 && len(p.IssuerOptions) == 0 {
+		l.report(path, "no shims or issuers configured, so no token can be parsed")
+	}
+
+	elixirClients := make(map[string]bool)
+	for i, s := range p.Shims {
+		shimPath := fmt.Sprintf("%s.shims[%d]", path, i)
+		for _, prev := range p.Shims[:i] {
+			if proto.Equal(prev, s) {
+				l.warn(shimPath, "unreachable: identical to an earlier shim")
+				break
+			}
+		}
+		switch s := s.Shim.(type) {
+		case *Shim_Elixir_:
+			if s.Elixir.ClientId == "" {
+				l.report(shimPath+".elixir", "missing client_id")
+			}
+			elixirClients[s.Elixir.ClientId] = true
+		case *Shim_Introspection_:
+			l.introspection(shimPath+".introspection", s.Introspection)
+		case *Shim_Plugin:
+			registryMu.RLock()
+			_, ok := shims[s.Plugin.Name]
+			registryMu.RUnlock()
+			if !ok {
+				l.report(shimPath+".plugin", "no shim registered as %q", s.Plugin.Name)
+			}
+		default:
+			l.report(shimPath, "no shim set")
+		}
+	}
+
+	// Entries of issuers are checked as if they were issuer_options with a
+	// single audience.
+	type issuerEntry struct {
+		path, issuer string
+		options      *IssuerOptions
+		shorthand    bool
+	}
+	var entries []issuerEntry
+	for _, issuer := range sortedKeys(p.Issuers) {
+		entries = append(entries, issuerEntry{
+			path:      fmt.Sprintf("%s.issuers[%q]", path, issuer),
+			issuer:    issuer,
+			options:   &IssuerOptions{Audiences: []string{p.Issuers[issuer]}},
+			shorthand: true,
+		})
+	}
+	var optionIssuers []string
+	for issuer := range p.IssuerOptions {
+		optionIssuers = append(optionIssuers, issuer)
+	}
+	sort.Strings(optionIssuers)
+	for _, issuer := range optionIssuers {
+		entries = append(entries, issuerEntry{
+			path:    fmt.Sprintf("%s.issuer_options[%q]", path, issuer),
+			issuer:  issuer,
+			options: p.IssuerOptions[issuer],
+		})
+	}
+
+	normalized := make(map[string]string)
+	for _, e := range entries {
+		u, err := url.Parse(e.issuer)
+		if err != nil {
+			l.report(e.path, "malformed issuer URL: %v", err)
+			continue
+		}
+		if u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+			l.report(e.path, "issuer must be an https URL without a query or fragment")
+			continue
+		}
+		key := strings.ToLower(u.Host) + strings.TrimSuffix(u.Path, "/")
+		if prev, ok := normalized[key]; ok {
+			l.report(e.path, "duplicates issuer %q", prev)
+		}
+		normalized[key] = e.issuer
+		if e.shorthand {
+			if p.Issuers[e.issuer] == "" {
+				l.report(e.path, "missing client ID")
+			}
+		} else {
+			l.issuerOptions(e.path, e.options)
+		}
+		unreachable := len(e.options.GetAudiences()) > 0
+		for _, aud := range e.options.GetAudiences() {
+			unreachable = unreachable && elixirClients[aud]
+		}
+		if key == strings.TrimSuffix(strings.TrimPrefix(elixir.Issuer, "https://"), "/") && unreachable {
+			l.warn(e.path, "unreachable: tokens from this issuer are handled by the elixir shim")
+		}
+	}
+	if p.DenyList != nil {
+		l.denyList(path+".deny_list", p.DenyList)
+	}
+}
+
+// outcome is the statically known result of a validator.
+type outcome int
+
+const (
+	unknown outcome = iota
+	alwaysTrue
+	alwaysFalse
+)
+
+// validator checks v and returns its statically known outcome.  Combinators
+// whose outcome is fixed are reported unless that is already implied by a
+// report about an enclosing validator.
+func (l *linter) validator(path string, v *Validator) outcome {
+	if v == nil {
+		return alwaysFalse
+	}
+	switch v := v.Validator.(type) {
+	case *Validator_And_:
+		if len(v.And.Validators) == 0 {
+			l.report(path+".and", "no validators")
+		}
+		return l.combinator(path+".and", v.And.Validators, alwaysFalse)
+
+	case *Validator_Or_:
+		if len(v.Or.Validators) == 0 {
+			l.report(path+".or", "no validators")
+		}
+		return l.combinator(path+".or", v.Or.Validators, alwaysTrue)
+
+	case *Validator_Not_:
+		switch l.validator(path+".not.validator", v.Not.Validator) {
+		case alwaysTrue:
+			return alwaysFalse
+		case alwaysFalse:
+			return alwaysTrue
+		}
+		return unknown
+
+	case *Validator_AtLeast_:
+		return l.counting(path+".at_least", v.AtLeast.Validators, int(v.AtLeast.N), false)
+
+	case *Validator_Exactly_:
+		return l.counting(path+".exactly", v.Exactly.Validators, int(v.Exactly.N), true)
+
+	case *Validator_Simple_:
+		l.claims(path+".simple.claims", sortedKeys(v.Simple.Claims))
+		var typed []string
+		for name := range v.Simple.TypedClaims {
+			typed = append(typed, name)
+		}
+		sort.Strings(typed)
+		l.claims(path+".simple.typed_claims", typed)
+		if len(v.Simple.Claims) == 0 && len(v.Simple.TypedClaims) == 0 {
+			return alwaysTrue
+		}
+		return unknown
+
+	case *Validator_Sourced_:
+		var names []string
+		for name := range v.Sourced.Claims {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		l.claims(path+".sourced.claims", names)
+		if len(names) == 0 {
+			return alwaysTrue
+		}
+		return unknown
+
+	case *Validator_Timed_:
+		var names []string
+		for name := range v.Timed.Claims {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		l.claims(path+".timed.claims", names)
+		for _, name := range names {
+			claimPath := fmt.Sprintf("%s.timed.claims[%q]", path, name)
+			if _, ok := validator.FieldType(name); ok && !validator.HasTimestamps(name) {
+				l.report(claimPath, "claim has no timestamps")
+			}
+			c := v.Timed.Claims[name]
+			for _, d := range []string{c.MaxAge, c.MinRemaining} {
+				if _, err := time.ParseDuration(d); d != "" && err != nil {
+					l.report(claimPath, "%v", err)
+				}
+			}
+		}
+		if len(names) == 0 {
+			return alwaysTrue
+		}
+		return unknown
+
+	case *Validator_Visa_:
+		if _, err := buildVisa(v.Visa); err != nil {
+			l.report(path+".visa", "%v", err)
+			return alwaysFalse
+		}
+		return unknown
+
+	case *Validator_Groups_:
+		l.groupFile(path+".groups.file", v.Groups.File)
+		if len(v.Groups.Groups) == 0 {
+			l.report(path+".groups", "no groups")
+			return alwaysFalse
+		}
+		return unknown
+
+	case *Validator_Match_:
+		var names []string
+		for name := range v.Match.Claims {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		l.claims(path+".match.claims", names)
+		for _, name := range names {
+			claimPath := fmt.Sprintf("%s.match.claims[%q]", path, name)
+			if typ, ok := validator.FieldType(name); ok && typ.Kind() != reflect.String {
+				l.report(claimPath, "claim is a %v, not a string", typ)
+			}
+			if _, err := buildMatcher(v.Match.Claims[name]); err != nil {
+				l.report(claimPath, "%v", err)
+			}
+		}
+		if len(names) == 0 {
+			return alwaysTrue
+		}
+		return unknown
+
+	case *Validator_Expression_:
+		if _, err := buildExpression(v.Expression); err != nil {
+			l.report(path+".expression", "%v", err)
+			return alwaysFalse
+		}
+		return unknown
+
+	case *Validator_Constant_:
+		if v.Constant.Value {
+			return alwaysTrue
+		}
+		return alwaysFalse
+
+	case *Validator_Plugin:
+		registryMu.RLock()
+		_, ok := validators[v.Plugin.Name]
+		registryMu.RUnlock()
+		if !ok {
+			l.report(path+".plugin", "no validator registered as %q", v.Plugin.Name)
+		}
+		return unknown
+
+	default:
+		l.report(path, "no validator set")
+		return alwaysFalse
+	}
+}
+
+// combinator checks the children of an And (short-circuiting on alwaysFalse)
+// or an Or (short-circuiting on alwaysTrue).
+func (l *linter) combinator(path string, vs []*Validator, dominant outcome) outcome {
+	identity := alwaysTrue
+	if dominant == alwaysTrue {
+		identity = alwaysFalse
+	}
+	result := identity
+	for i, v := range vs {
+		childPath := fmt.Sprintf("%s.validators[%d]", path, i)
+		o := l.validator(childPath, v)
+		switch {
+		case o == dominant:
+			if _, ok := v.GetValidator().(*Validator_Constant_); !ok {
+				l.warn(childPath, "is constant, making the enclosing validator constant")
+			}
+			result = dominant
+		case o == unknown && result != dominant:
+			result = unknown
+		}
+	}
+	return result
+}
+
+// counting checks the children of an AtLeast or, if exact is set, an Exactly
+// validator that requires n of them to succeed.
+func (l *linter) counting(path string, vs []*Validator, n int, exact bool) outcome {
+	if len(vs) == 0 {
+		l.report(path, "no validators")
+	}
+	if n > len(vs) {
+		l.report(path, "requires %d validators to succeed but only has %d", n, len(vs))
+	}
+	var trues, unknowns int
+	for i, v := range vs {
+		switch l.validator(fmt.Sprintf("%s.validators[%d]", path, i), v) {
+		case alwaysTrue:
+			trues++
+		case unknown:
+			unknowns++
+		}
+	}
+	switch {
+	case trues+unknowns < n:
+		return alwaysFalse
+	case exact && trues > n:
+		return alwaysFalse
+	case !exact && trues >= n:
+		return alwaysTrue
+	case exact && unknowns == 0:
+		return alwaysTrue
+	}
+	return unknown
+}
+
+// introspection checks the configuration of an introspection shim.
+func (l *linter) introspection(path string, i *Shim_Introspection) {
+	if !isHTTPS(i.Endpoint) {
+		l.report(path, "endpoint must be an https URL")
+	}
+	if i.Issuer == "" {
+		l.report(path, "missing issuer")
+	}
+	if i.ClientId == "" {
+		l.report(path, "missing client_id")
+	}
+	if _, err := introspectionConfig(i); err != nil {
+		l.report(path, "%v", err)
+	}
+}
+
+// userInfo checks the configuration of a UserInfo enricher without contacting
+// its issuer.
+func (l *linter) userInfo(path string, u *Enricher_UserInfo) {
+	if !isHTTPS(u.Issuer) {
+		l.report(path, "issuer must be an https URL")
+	}
+	if u.Endpoint != "" && !isHTTPS(u.Endpoint) {
+		l.report(path, "endpoint must be an https URL")
+	}
+	if _, err := userInfoConfig(u); err != nil {
+		l.report(path, "%v", err)
+	}
+}
+
+func isHTTPS(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// signingAlgorithms are the algorithms supported by the OIDC verifier.
+var signingAlgorithms = map[string]bool{
+	oidc.RS256: true, oidc.RS384: true, oidc.RS512: true,
+	oidc.ES256: true, oidc.ES384: true, oidc.ES512: true,
+	oidc.PS256: true, oidc.PS384: true, oidc.PS512: true,
+}
+
+// issuerOptions checks the options of an issuer.
+func (l *linter) issuerOptions(path string, o *IssuerOptions) {
+	if len(o.GetAudiences()) == 0 {
+		l.report(path, "no audiences")
+	}
+	for i, aud := range o.GetAudiences() {
+		if aud == "" {
+			l.report(fmt.Sprintf("%s.audiences[%d]", path, i), "empty audience")
+		}
+	}
+	for i, alg := range o.GetAlgorithms() {
+		if !signingAlgorithms[alg] {
+			l.report(fmt.Sprintf("%s.algorithms[%d]", path, i), "unsupported signing algorithm %q", alg)
+		}
+	}
+	if _, err := buildIssuerOptions(o); err != nil {
+		l.report(path, "%v", err)
+	}
+}
+
+// groupFile checks the configuration of a group membership file without
+// reading it.
+func (l *linter) groupFile(path string, f *GroupFile) {
+	if f.GetPath() == "" {
+		l.report(path, "no path")
+	}
+	if _, err := reloadInterval(f.GetReloadInterval()); err != nil {
+		l.report(path, "%v", err)
+	}
+}
+
+// denyList checks the configuration of a deny list without reading it.
+func (l *linter) denyList(path string, d *DenyList) {
+	switch d := d.DenyList.(type) {
+	case *DenyList_File_:
+		if d.File.Path == "" {
+			l.report(path+".file", "no path")
+		}
+		if _, err := reloadInterval(d.File.ReloadInterval); err != nil {
+			l.report(path+".file", "%v", err)
+		}
+	case *DenyList_Plugin:
+		registryMu.RLock()
+		_, ok := denyLists[d.Plugin.Name]
+		registryMu.RUnlock()
+		if !ok {
+			l.report(path+".plugin", "no deny list registered as %q", d.Plugin.Name)
+		}
+	default:
+		l.report(path, "no deny list set")
+	}
+}
+
+// claims checks that each claim names a field of ga4gh.Identity.
+func (l *linter) claims(path string, names []string) {
+	for _, name := range names {
+		if _, ok := validator.FieldType(name); !ok {
+			l.report(fmt.Sprintf("%s[%q]", path, name), "no field named %q on ga4gh.Identity", name)
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
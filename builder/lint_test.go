@@ -0,0 +1,273 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestLint(t *testing.T) {
+	const parser = `parser { issuers { key: "https://idp.example.com" value: "client" } } `
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "clean",
+			in:   parser + `validator { simple { claims { key: "Role" value: "researcher" } } }`,
+		},
+		{
+			name: "unknown identity field",
+			in:   parser + `validator { simple { claims { key: "Rol" value: "researcher" } } }`,
+			want: []string{`validator.simple.claims["Rol"]: no field named "Rol" on ga4gh.Identity`},
+		},
+		{
+			name: "empty and",
+			in:   parser + `validator { and {} }`,
+			want: []string{
+				"validator.and: no validators",
+				"validator: warning: accepts every identity",
+			},
+		},
+		{
+			name: "constant child of or",
+			in: parser + `validator { or {
+				validators { simple { claims { key: "Role" value: "researcher" } } }
+				validators { simple {} }
+			} }`,
+			want: []string{
+				"validator.or.validators[1]: warning: is constant, making the enclosing validator constant",
+				"validator: warning: accepts every identity",
+			},
+		},
+		{
+			name: "unsatisfiable at_least",
+			in: parser + `validator { at_least {
+				n: 3
+				validators { simple { claims { key: "Role" value: "researcher" } } }
+				validators { not { validator { constant { value: true } } } }
+			} }`,
+			want: []string{
+				"validator.at_least: requires 3 validators to succeed but only has 2",
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "missing validator",
+			in:   parser,
+			want: []string{"validator: warning: rejects every identity"},
+		},
+		{
+			name: "unreachable shim",
+			in: `parser {
+				shims { elixir { client_id: "client" } }
+				shims { elixir { client_id: "client" } }
+				issuers { key: "https://login.elixir-czech.org/oidc" value: "client" }
+			}
+			validator { simple { claims { key: "Role" value: "researcher" } } }`,
+			want: []string{
+				"parser.shims[1]: warning: unreachable: identical to an earlier shim",
+				`parser.issuers["https://login.elixir-czech.org/oidc"]: warning: unreachable: tokens from this issuer are handled by the elixir shim`,
+			},
+		},
+		{
+			name: "bad issuers",
+			in: `parser {
+				issuers { key: "https://IDP.example.com/" value: "client" }
+				issuers { key: "https://idp.example.com" value: "client" }
+				issuers { key: "idp.example.com" value: "client" }
+			}
+			validator { simple { claims { key: "Role" value: "researcher" } } }`,
+			want: []string{
+				`parser.issuers["https://idp.example.com"]: duplicates issuer "https://IDP.example.com/"`,
+				`parser.issuers["idp.example.com"]: issuer must be an https URL without a query or fragment`,
+			},
+		},
+		{
+			name: "bad user info",
+			in: parser + `validator { constant { value: false } }
+			enrichers { user_info { issuer: "https://idp.example.com" endpoint: "http://idp.example.com/userinfo" } }
+			enrichers { user_info { timeout: "soon" cache_ttl: "1m" } }
+			enrichers { user_info { issuer: "https://login.elixir-czech.org/oidc/" format: ELIXIR } }`,
+			want: []string{
+				"validator: warning: rejects every identity",
+				"enrichers[0].user_info: endpoint must be an https URL",
+				"enrichers[1].user_info: issuer must be an https URL",
+				`enrichers[1].user_info: parsing timeout: time: invalid duration "soon"`,
+			},
+		},
+		{
+			name: "bad introspection",
+			in: `parser { shims { introspection {
+				endpoint: "http://idp.example.com/introspect"
+				timeout: "soon"
+			} } }
+			validator { simple { claims { key: "Role" value: "researcher" } } }`,
+			want: []string{
+				"parser.shims[0].introspection: endpoint must be an https URL",
+				"parser.shims[0].introspection: missing issuer",
+				"parser.shims[0].introspection: missing client_id",
+				`parser.shims[0].introspection: parsing timeout: time: invalid duration "soon"`,
+			},
+		},
+		{
+			name: "bad issuer options",
+			in: `parser {
+				issuers { key: "https://idp.example.com" value: "client" }
+				issuer_options { key: "https://idp.example.com/" value { audiences: "client" } }
+				issuer_options { key: "https://other.example.com" value {
+					audiences: ""
+					algorithms: "HS256"
+					clock_skew: "a little"
+				} }
+				issuer_options { key: "https://third.example.com" value {} }
+			}
+			validator { simple { claims { key: "Role" value: "researcher" } } }`,
+			want: []string{
+				`parser.issuer_options["https://idp.example.com/"]: duplicates issuer "https://idp.example.com"`,
+				`parser.issuer_options["https://other.example.com"].audiences[0]: empty audience`,
+				`parser.issuer_options["https://other.example.com"].algorithms[0]: unsupported signing algorithm "HS256"`,
+				`parser.issuer_options["https://other.example.com"]: parsing clock skew: time: invalid duration "a little"`,
+				`parser.issuer_options["https://third.example.com"]: no audiences`,
+			},
+		},
+		{
+			name: "bad match",
+			in: parser + `validator { match {
+				claims { key: "BonaFide" value { prefix: "t" } }
+				claims { key: "Role" value { regexp: "(researcher" } }
+			} }`,
+			want: []string{
+				`validator.match.claims["BonaFide"]: claim is a bool, not a string`,
+				`validator.match.claims["Role"]: compiling regexp: error parsing regexp: missing closing ): ` + "`(researcher`",
+			},
+		},
+		{
+			name: "bad timed",
+			in: parser + `validator { timed {
+				claims { key: "Issuer" value { max_age: "1h" } }
+				claims { key: "Role" value { min_remaining: "1 hour" } }
+			} }`,
+			want: []string{
+				`validator.timed.claims["Issuer"]: claim has no timestamps`,
+				`validator.timed.claims["Role"]: time: unknown unit " hour" in duration "1 hour"`,
+			},
+		},
+		{
+			name: "datasets",
+			in: parser + `validator { constant { value: true } }
+			datasets { key: "phs000710" value { constant { value: false } } }
+			datasets { key: "phs000711" value { simple { claims { key: "Rol" value: "x" } } } }`,
+			want: []string{
+				"validator: warning: accepts every identity",
+				`datasets["phs000711"].simple.claims["Rol"]: no field named "Rol" on ga4gh.Identity`,
+			},
+		},
+		{
+			name: "bad visa",
+			in:   parser + `validator { visa { type: "ControlledAccessGrants" value: "phs000710" } }`,
+			want: []string{
+				`validator.visa: "phs000710" does not start with const:, pattern: or split_pattern:`,
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "bad visa max age",
+			in:   parser + `validator { visa { type: "ControlledAccessGrants" max_age: "a year" } }`,
+			want: []string{
+				`validator.visa: parsing max age: time: invalid duration "a year"`,
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "bad cache",
+			in:   parser + `validator { constant { value: false } } cache { ttl: "forever" }`,
+			want: []string{
+				"validator: warning: rejects every identity",
+				`cache: parsing ttl: time: invalid duration "forever"`,
+			},
+		},
+		{
+			name: "bad groups",
+			in: parser + `validator { groups { file { reload_interval: "often" } } }
+			enrichers { groups { file { path: "groups.csv" reload_interval: "1m" } } }
+			enrichers {}`,
+			want: []string{
+				"validator.groups.file: no path",
+				`validator.groups.file: parsing reload interval: time: invalid duration "often"`,
+				"validator.groups: no groups",
+				"validator: warning: rejects every identity",
+				"enrichers[1]: no enricher set",
+			},
+		},
+		{
+			name: "bad deny lists",
+			in: `parser {
+				issuers { key: "https://idp.example.com" value: "client" }
+				deny_list { file { reload_interval: "often" } }
+			}
+			validator { constant { value: false } }`,
+			want: []string{
+				"parser.deny_list.file: no path",
+				`parser.deny_list.file: parsing reload interval: time: invalid duration "often"`,
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "unregistered deny list",
+			in: `parser {
+				issuers { key: "https://idp.example.com" value: "client" }
+				deny_list { plugin { name: "missing" } }
+			}
+			validator { constant { value: false } }`,
+			want: []string{
+				`parser.deny_list.plugin: no deny list registered as "missing"`,
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "bad expression",
+			in:   parser + `validator { expression { source: "identity.iss in trusted" } }`,
+			want: []string{
+				`validator.expression: offset 16: undefined variable "trusted"`,
+				"validator: warning: rejects every identity",
+			},
+		},
+		{
+			name: "unregistered plugin",
+			in:   parser + `validator { plugin { name: "missing" } }`,
+			want: []string{`validator.plugin: no validator registered as "missing"`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var e Evaluator
+			if err := proto.UnmarshalText(test.in, &e); err != nil {
+				t.Fatalf("Error parsing evaluator: %v", err)
+			}
+			var got []string
+			for _, issue := range Lint(&e) {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("Lint() = %q, want = %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// ShimFactory constructs a ga4gh.Shim from the configuration of a Plugin.
+// The configuration is the message unpacked from Plugin.config, or nil if no
+// configuration was provided.
+type ShimFactory func(ctx context.Context, config proto.Message) (ga4gh.Shim, error)
+
+// ValidatorFactory constructs a ga4gh.Validator from the configuration of a
+// Plugin.  The configuration is the message unpacked from Plugin.config, or
+// nil if no configuration was provided.
+type ValidatorFactory func(ctx context.Context, config proto.Message) (ga4gh.Validator, error)
+
+// DenyListFactory constructs a ga4gh.DenyList from the configuration of a
+// Plugin.  The configuration is the message unpacked from Plugin.config, or
+// nil if no configuration was provided.
+type DenyListFactory func(ctx context.Context, config proto.Message) (ga4gh.DenyList, error)
+
+var (
+	registryMu sync.RWMutex
+	shims      = make(map[string]ShimFactory)
+	validators = make(map[string]ValidatorFactory)
+	denyLists  = make(map[string]DenyListFactory)
+)
+
+// RegisterShim makes a shim implementation available to Build under name.  The
+// message type of its configuration, if any, must be registered with the
+// proto package.  It panics if called twice with the same name, and is
+// usually called from the init function of the implementing package.
+func RegisterShim(name string, factory ShimFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := shims[name]; ok {
+		panic(fmt.Sprintf("builder: shim %q registered twice", name))
+	}
+	shims[name] = factory
+}
+
+// RegisterValidator makes a validator implementation available to Build under
+// name.  The message type of its configuration, if any, must be registered
+// with the proto package.  It panics if called twice with the same name, and
+// is usually called from the init function of the implementing package.
+func RegisterValidator(name string, factory ValidatorFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := validators[name]; ok {
+		panic(fmt.Sprintf("builder: validator %q registered twice", name))
+	}
+	validators[name] = factory
+}
+
+// RegisterDenyList makes a deny list implementation available to Build under
+// name.  The message type of its configuration, if any, must be registered
+// with the proto package.  It panics if called twice with the same name, and
+// is usually called from the init function of the implementing package.
+func RegisterDenyList(name string, factory DenyListFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := denyLists[name]; ok {
+		panic(fmt.Sprintf("builder: deny list %q registered twice", name))
+	}
+	denyLists[name] = factory
+}
+
+func buildPluginShim(ctx context.Context, p *Plugin) (ga4gh.Shim, error) {
+	registryMu.RLock()
+	factory, ok := shims[p.Name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no shim registered as %q", p.Name)
+	}
+	config, err := pluginConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	return factory(ctx, config)
+}
+
+func buildPluginValidator(ctx context.Context, p *Plugin) (ga4gh.Validator, error) {
+	registryMu.RLock()
+	factory, ok := validators[p.Name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no validator registered as %q", p.Name)
+	}
+	config, err := pluginConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	return factory(ctx, config)
+}
+
+func buildPluginDenyList(ctx context.Context, p *Plugin) (ga4gh.DenyList, error) {
+	registryMu.RLock()
+	factory, ok := denyLists[p.Name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no deny list registered as %q", p.Name)
+	}
+	config, err := pluginConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	return factory(ctx, config)
+}
+
+func pluginConfig(p *Plugin) (proto.Message, error) {
+	if p.Config == nil {
+		return nil, nil
+	}
+	var config ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(p.Config, &config); err != nil {
+		return nil, fmt.Errorf("unpacking configuration for %q: %v", p.Name, err)
+	}
+	return config.Message, nil
+}
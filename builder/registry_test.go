@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/shim"
+	"github.com/googlegenomics/ga4gh-identity/validator"
+)
+
+func init() {
+	RegisterShim("test-static", func(ctx context.Context, config proto.Message) (ga4gh.Shim, error) {
+		return &shim.Static{Identity: id}, nil
+	})
+	RegisterValidator("test-role", func(ctx context.Context, config proto.Message) (ga4gh.Validator, error) {
+		s, ok := config.(*structpb.Struct)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %T configuration", config)
+		}
+		return validator.Simple{"Role": s.Fields["role"].GetStringValue()}, nil
+	})
+	RegisterDenyList("test-deny-all", func(ctx context.Context, config proto.Message) (ga4gh.DenyList, error) {
+		return denyAll{}, nil
+	})
+}
+
+type denyAll struct{}
+
+func (denyAll) Denied(ctx context.Context, token ga4gh.TokenID) (bool, error) {
+	return true, nil
+}
+
+func TestBuildPlugins(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		ok    bool
+		error bool
+	}{
+		{
+			name: "matching role",
+			in: `
+				parser { shims { plugin { name: "test-static" } } }
+				validator {
+					plugin {
+						name: "test-role"
+						config {
+							[type.googleapis.com/google.protobuf.Struct] {
+								fields { key: "role" value { string_value: "human" } }
+							}
+						}
+					}
+				}`,
+			ok: true,
+		},
+		{
+			name: "mismatched role",
+			in: `
+				parser { shims { plugin { name: "test-static" } } }
+				validator {
+					plugin {
+						name: "test-role"
+						config {
+							[type.googleapis.com/google.protobuf.Struct] {
+								fields { key: "role" value { string_value: "robot" } }
+							}
+						}
+					}
+				}`,
+			ok: false,
+		},
+		{
+			name:  "missing configuration",
+			in:    `parser { shims { plugin { name: "test-static" } } } validator { plugin { name: "test-role" } }`,
+			error: true,
+		},
+		{
+			name: "denied",
+			in: `
+				parser {
+					shims { plugin { name: "test-static" } }
+					deny_list { plugin { name: "test-deny-all" } }
+				}
+				validator { constant { value: true } }`,
+			ok: false,
+		},
+		{
+			name:  "unregistered deny list",
+			in:    `parser { shims { plugin { name: "test-static" } } deny_list { plugin { name: "missing" } } } validator { constant { value: true } }`,
+			error: true,
+		},
+		{
+			name:  "unregistered validator",
+			in:    `parser { shims { plugin { name: "test-static" } } } validator { plugin { name: "missing" } }`,
+			error: true,
+		},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var e Evaluator
+			if err := proto.UnmarshalText(test.in, &e); err != nil {
+				t.Fatalf("Error parsing evaluator: %v", err)
+			}
+			ev, err := Build(ctx, &e)
+			if (err != nil) != test.error {
+				t.Fatalf("Build() = %v, want error = %v", err, test.error)
+			}
+			if err != nil {
+				return
+			}
+			_, err = ev.Evaluate(ctx, "token")
+			if ok := err == nil; ok != test.ok {
+				t.Fatalf("Evaluate() = %v, want ok = %v", err, test.ok)
+			}
+		})
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("RegisterValidator did not panic on a duplicate name")
+		}
+	}()
+	RegisterValidator("test-role", nil)
+}
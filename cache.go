@@ -0,0 +1,187 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// DefaultCacheEntries is the number of decisions held by a DecisionCache
+// created with a maxEntries of zero.
+const DefaultCacheEntries = 10000
+
+// DecisionCache holds the identities of authorization strings that were
+// successfully evaluated by an Evaluator, so that repeated requests with the
+// same token are not parsed and validated again.  Entries are keyed by a
+// SHA-256 hash of the authorization string, which is never stored itself.
+//
+// An entry is kept until the earliest of the expiry of the token, if it is a
+// JWT with an exp claim, the expiry of any of the identity's claims and visas,
+// and the TTL of the cache.  Validators that depend on the time are therefore only
+// re-evaluated when an entry expires.  Failed evaluations are not cached.
+type DecisionCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	// order holds *cacheEntry values, most recently used first.
+	order *list.List
+	stats CacheStats
+}
+
+// CacheStats describes the use of a DecisionCache.
+type CacheStats struct {
+	// Hits and Misses count lookups that did and did not find a current
+	// entry.
+	Hits, Misses uint64
+	// Expirations counts entries that were found to have expired, and
+	// Evictions entries that were removed to make room for new ones.
+	Expirations, Evictions uint64
+	// Entries is the number of entries currently held.
+	Entries int
+}
+
+type cacheEntry struct {
+	key      [sha256.Size]byte
+	identity *Identity
+	expires  time.Time
+}
+
+// NewDecisionCache creates a DecisionCache that holds at most maxEntries
+// decisions, or DefaultCacheEntries if maxEntries is zero, for no longer than
+// ttl.  If ttl is zero then only evaluations whose token, claims or visas have
+// an expiry time are cached.
+func NewDecisionCache(maxEntries int, ttl time.Duration) *DecisionCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+	return &DecisionCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Stats returns a snapshot of the statistics of c.
+func (c *DecisionCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+func cacheKey(auth string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(auth))
+}
+
+// get returns the identity cached for key, if there is one that has not
+// expired at now.
+func (c *DecisionCache) get(key [sha256.Size]byte, now time.Time) (*Identity, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return entry.identity, true
+}
+
+// add caches identity, evaluated from auth at now, under key.
+func (c *DecisionCache) add(key [sha256.Size]byte, auth string, identity *Identity, now time.Time) {
+	expires := c.expiry(auth, identity, now)
+	if !now.Before(expires) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, identity: identity, expires: expires})
+}
+
+func (c *DecisionCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// expiry returns the time until which the evaluation of auth into identity at
+// now may be cached, which is the zero time if it may not be cached at all.
+func (c *DecisionCache) expiry(auth string, identity *Identity, now time.Time) time.Time {
+	var expires time.Time
+	earliest := func(t time.Time) {
+		if expires.IsZero() || t.Before(expires) {
+			expires = t
+		}
+	}
+	if c.ttl > 0 {
+		earliest(now.Add(c.ttl))
+	}
+	// The token has already been verified by the Parser, so its claims can be
+	// read without verification.
+	if parsed, err := jwt.ParseSigned(auth); err == nil {
+		var claims jwt.Claims
+		if err := parsed.UnsafeClaimsWithoutVerification(&claims); err == nil && claims.Expiry != 0 {
+			earliest(claims.Expiry.Time())
+		}
+	}
+	var claims []int64
+	for _, values := range [][]StringValue{
+		identity.OriginOrganization,
+		identity.AcademicInstitutionAffiliations,
+		identity.Role,
+		identity.HasAcknowledgedEthicsTerms,
+		identity.Groups,
+	} {
+		for _, v := range values {
+			claims = append(claims, v.Expires)
+		}
+	}
+	for _, v := range identity.BonaFide {
+		claims = append(claims, v.Expires)
+	}
+	for _, v := range identity.Visas {
+		claims = append(claims, v.Expires)
+	}
+	for _, e := range claims {
+		if e != 0 {
+			earliest(time.Unix(e, 0))
+		}
+	}
+	return expires
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// countingShim returns a copy of identity for every authorization string and
+// counts how often it is called.
+type countingShim struct {
+	identity Identity
+	calls    int
+}
+
+func (s *countingShim) Shim(ctx context.Context, auth string) (*Identity, error) {
+	s.calls++
+	id := s.identity
+	return &id, nil
+}
+
+type constantValidator bool
+
+func (v constantValidator) Validate(ctx context.Context, identity *Identity) (bool, error) {
+	return bool(v), nil
+}
+
+// clock is a settable clock for use with NewClockContext.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newCachedEvaluator(t *testing.T, cache *DecisionCache, identity Identity, valid bool) (*Evaluator, *countingShim) {
+	shim := &countingShim{identity: identity}
+	parser, err := NewParser(context.Background(), []Shim{shim}, nil)
+	if err != nil {
+		t.Fatalf("NewParser() = %v", err)
+	}
+	return &Evaluator{Parser: parser, Validator: constantValidator(valid), Cache: cache}, shim
+}
+
+func TestDecisionCache(t *testing.T) {
+	c := &clock{now: time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)}
+	ctx := NewClockContext(context.Background(), c.Now)
+	ev, shim := newCachedEvaluator(t, NewDecisionCache(2, time.Minute), Identity{Subject: "alice"}, true)
+
+	evaluate := func(auth string, wantCalls int) {
+		t.Helper()
+		id, err := ev.Evaluate(ctx, auth)
+		if err != nil {
+			t.Fatalf("Evaluate(%q) = %v", auth, err)
+		}
+		if id.Subject != "alice" {
+			t.Fatalf("Evaluate(%q) returned subject %q, want %q", auth, id.Subject, "alice")
+		}
+		if shim.calls != wantCalls {
+			t.Fatalf("after Evaluate(%q) the shim was called %d times, want %d", auth, shim.calls, wantCalls)
+		}
+	}
+
+	evaluate("a", 1)
+	evaluate("a", 1)
+	evaluate("b", 2)
+	evaluate("a", 2)
+	// The cache is full, so adding c evicts b, the least recently used.
+	evaluate("c", 3)
+	evaluate("b", 4)
+	evaluate("c", 4)
+
+	c.now = c.now.Add(time.Minute)
+	evaluate("c", 5)
+
+	want := CacheStats{Hits: 3, Misses: 5, Expirations: 1, Evictions: 2, Entries: 2}
+	if got := ev.Cache.Stats(); got != want {
+		t.Fatalf("Stats() = %+v, want = %+v", got, want)
+	}
+}
+
+func TestDecisionCacheExpiry(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+	if err != nil {
+		t.Fatalf("Error creating signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Expiry: jwt.NewNumericDate(now.Add(time.Minute))}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		auth     string
+		identity Identity
+		valid    bool
+		// after is how long after now the identity is evaluated again, and
+		// cached whether it should still be cached.
+		after  time.Duration
+		cached bool
+	}{
+		{name: "within ttl", ttl: time.Hour, auth: "opaque", valid: true, after: 59 * time.Minute, cached: true},
+		{name: "token exp before ttl", ttl: time.Hour, auth: token, valid: true, after: 2 * time.Minute, cached: false},
+		{name: "token exp without ttl", auth: token, valid: true, after: 30 * time.Second, cached: true},
+		{name: "no ttl or exp", auth: "opaque", valid: true, after: time.Second, cached: false},
+		{
+			name:     "visa expiry",
+			ttl:      time.Hour,
+			auth:     "opaque",
+			identity: Identity{Visas: []Visa{{Type: "ResearcherStatus", Expires: now.Add(time.Minute).Unix()}}},
+			valid:    true,
+			after:    2 * time.Minute,
+			cached:   false,
+		},
+		{
+			name:     "claim expiry before token exp",
+			auth:     token,
+			identity: Identity{Role: []StringValue{{Value: "researcher", Expires: now.Add(10 * time.Second).Unix()}}},
+			valid:    true,
+			after:    30 * time.Second,
+			cached:   false,
+		},
+		{
+			name:     "bona fide expiry",
+			ttl:      time.Hour,
+			auth:     "opaque",
+			identity: Identity{BonaFide: []BoolValue{{Value: true, Expires: now.Add(time.Minute).Unix()}}},
+			valid:    true,
+			after:    2 * time.Minute,
+			cached:   false,
+		},
+		{name: "failures", ttl: time.Hour, auth: "opaque", valid: false, after: time.Second, cached: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &clock{now: now}
+			ctx := NewClockContext(context.Background(), c.Now)
+			ev, shim := newCachedEvaluator(t, NewDecisionCache(0, test.ttl), test.identity, test.valid)
+			ev.Evaluate(ctx, test.auth)
+			c.now = c.now.Add(test.after)
+			ev.Evaluate(ctx, test.auth)
+			if got := shim.calls == 1; got != test.cached {
+				t.Fatalf("second evaluation cached = %v, want = %v", got, test.cached)
+			}
+		})
+	}
+}
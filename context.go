@@ -14,11 +14,18 @@
 
 package ga4gh
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type key int
 
-const identityKey key = 0
+const (
+	identityKey key = iota
+	clockKey
+	authorizationKey
+)
 
 // NewIdentityContext creates a new context.Conext from ctx that carries
 // identity.
@@ -32,3 +39,33 @@ func IdentityFromContext(ctx context.Context) (*Identity, bool) {
 	identity, ok := ctx.Value(identityKey).(*Identity)
 	return identity, ok
 }
+
+// NewAuthorizationContext creates a new context.Context from ctx that carries
+// the authorization string that is being evaluated.  Evaluator uses it to make
+// the string available to its Enrichers.
+func NewAuthorizationContext(ctx context.Context, auth string) context.Context {
+	return context.WithValue(ctx, authorizationKey, auth)
+}
+
+// AuthorizationFromContext returns the authorization string associated with
+// ctx.  If there is none then it returns ("", false).
+func AuthorizationFromContext(ctx context.Context) (string, bool) {
+	auth, ok := ctx.Value(authorizationKey).(string)
+	return auth, ok
+}
+
+// NewClockContext creates a new context.Context from ctx that carries a clock,
+// which is used instead of time.Now by code that asks for the current time
+// through Now.  It is intended for tests.
+func NewClockContext(ctx context.Context, now func() time.Time) context.Context {
+	return context.WithValue(ctx, clockKey, now)
+}
+
+// Now returns the current time according to the clock associated with ctx, or
+// time.Now if there is none.
+func Now(ctx context.Context) time.Time {
+	if now, ok := ctx.Value(clockKey).(func() time.Time); ok {
+		return now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// ErrDenied is returned by Parser.Parse for tokens that are on its deny list.
+var ErrDenied = errors.New("token is on the deny list")
+
+// TokenID identifies a token that was successfully parsed by a Parser.  ID is
+// the token's jti claim, which is empty if the token is not a JWT or has no
+// such claim.
+type TokenID struct {
+	ID      string
+	Issuer  string
+	Subject string
+}
+
+// DenyList is used to reject tokens that remain otherwise valid, for example
+// because they were leaked or the account they belong to was compromised.
+type DenyList interface {
+	// Denied reports whether token must be rejected.
+	Denied(ctx context.Context, token TokenID) (bool, error)
+}
+
+// checkDenyList returns ErrDenied if the token auth, which was parsed into id,
+// is on p.DenyList.
+func (p *Parser) checkDenyList(ctx context.Context, auth string, id *Identity) error {
+	if p.DenyList == nil {
+		return nil
+	}
+	token := TokenID{Issuer: id.Issuer, Subject: id.Subject}
+	// The token has already been verified, so its claims can be read without
+	// verification.
+	if parsed, err := jwt.ParseSigned(auth); err == nil {
+		var claims jwt.Claims
+		if err := parsed.UnsafeClaimsWithoutVerification(&claims); err == nil {
+			token.ID = claims.ID
+		}
+	}
+	denied, err := p.DenyList.Denied(ctx, token)
+	if err != nil {
+		return fmt.Errorf("checking deny list: %v", err)
+	}
+	if denied {
+		return ErrDenied
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package denylist provides a ga4gh.DenyList of revoked tokens and identities
+// that is managed in a local file.
+package denylist
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/internal/reloadfile"
+)
+
+// DefaultReloadInterval is the reload interval of a File opened with an
+// interval of zero.
+const DefaultReloadInterval = reloadfile.DefaultInterval
+
+// member identifies an identity by its issuer and subject.
+type member struct {
+	issuer, subject string
+}
+
+// entries are the contents of a deny list file.
+type entries struct {
+	ids      map[string]bool
+	subjects map[string]bool
+	members  map[member]bool
+}
+
+// File is a ga4gh.DenyList holding the entries listed in a file, which is
+// reloaded when it changes.  Each line of the file is one of:
+//
+//	jti <token ID>
+//	sub <subject>
+//	iss+sub <issuer> <subject>
+//
+// which deny tokens with the given jti claim, tokens of the given subject from
+// any issuer, and tokens of the given subject from the given issuer
+// respectively.  Empty lines and lines starting with "#" are ignored.
+type File struct {
+	file *reloadfile.File
+}
+
+// Open loads the deny list in the file at path.  When the list is consulted
+// after interval has passed since the file was last read, or
+// DefaultReloadInterval if interval is zero, the file is read again and the
+// entries are replaced if it has changed.  If the file cannot be read or
+// parsed then the previous entries remain in use.
+func Open(path string, interval time.Duration) (*File, error) {
+	f, err := reloadfile.Open(path, interval, func(b []byte) (interface{}, error) {
+		return parse(b)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &File{file: f}, nil
+}
+
+// Path returns the path of the file.
+func (f *File) Path() string {
+	return f.file.Path()
+}
+
+// Reload reads the file and, if it has changed, replaces the entries.  It
+// reports whether the entries were replaced.
+func (f *File) Reload() (bool, error) {
+	return f.file.Reload()
+}
+
+// Denied implements the ga4gh.DenyList interface.  The current time is taken
+// from ctx using ga4gh.Now.
+func (f *File) Denied(ctx context.Context, token ga4gh.TokenID) (bool, error) {
+	e := f.file.Value(ctx).(*entries)
+	switch {
+	case token.ID != "" && e.ids[token.ID]:
+		return true, nil
+	case token.Subject != "" && e.subjects[token.Subject]:
+		return true, nil
+	}
+	return e.members[member{issuer: token.Issuer, subject: token.Subject}], nil
+}
+
+func parse(b []byte) (*entries, error) {
+	e := &entries{
+		ids:      make(map[string]bool),
+		subjects: make(map[string]bool),
+		members:  make(map[member]bool),
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; s.Scan(); line++ {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch kind, args := fields[0], fields[1:]; {
+		case kind == "jti" && len(args) == 1:
+			e.ids[args[0]] = true
+		case kind == "sub" && len(args) == 1:
+			e.subjects[args[0]] = true
+		case kind == "iss+sub" && len(args) == 2:
+			e.members[member{issuer: args[0], subject: args[1]}] = true
+		case kind == "jti" || kind == "sub" || kind == "iss+sub":
+			return nil, fmt.Errorf("line %d: wrong number of values for %q", line, kind)
+		default:
+			return nil, fmt.Errorf("line %d: unknown entry type %q", line, kind)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
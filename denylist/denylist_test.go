@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package denylist
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+const issuer = "https://idp.example.com"
+
+func writeFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "denied.txt")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Error writing %q: %v", path, err)
+	}
+	return path
+}
+
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "denylist")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestDenied(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	f, err := Open(writeFile(t, dir, "# Leaked tokens.\n"+
+		"jti token-1\n"+
+		"\n"+
+		"sub mallory\n"+
+		"iss+sub "+issuer+" eve\n"), 0)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token ga4gh.TokenID
+		want  bool
+	}{
+		{name: "jti", token: ga4gh.TokenID{ID: "token-1", Issuer: issuer, Subject: "alice"}, want: true},
+		{name: "other jti", token: ga4gh.TokenID{ID: "token-2", Issuer: issuer, Subject: "alice"}, want: false},
+		{name: "subject", token: ga4gh.TokenID{Issuer: "https://other.example.com", Subject: "mallory"}, want: true},
+		{name: "issuer and subject", token: ga4gh.TokenID{Issuer: issuer, Subject: "eve"}, want: true},
+		{name: "subject from other issuer", token: ga4gh.TokenID{Issuer: "https://other.example.com", Subject: "eve"}, want: false},
+		{name: "empty", token: ga4gh.TokenID{}, want: false},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := f.Denied(ctx, test.token)
+			if err != nil {
+				t.Fatalf("Denied() = %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("Denied(%+v) = %v, want = %v", test.token, got, test.want)
+			}
+		})
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown type", content: "aud client\n"},
+		{name: "missing value", content: "jti\n"},
+		{name: "missing subject", content: "iss+sub " + issuer + "\n"},
+		{name: "extra value", content: "sub alice bob\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Open(writeFile(t, dir, test.content), 0); err == nil {
+				t.Fatal("Open() succeeded, want error")
+			}
+		})
+	}
+	if _, err := Open(filepath.Join(dir, "absent.txt"), 0); err == nil {
+		t.Fatal("Open() of a missing file succeeded, want error")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := writeFile(t, dir, "sub mallory\n")
+	f, err := Open(path, time.Minute)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	alice := ga4gh.TokenID{Issuer: issuer, Subject: "alice"}
+	check := func(want bool) {
+		t.Helper()
+		got, err := f.Denied(ctx, alice)
+		if err != nil {
+			t.Fatalf("Denied() = %v", err)
+		}
+		if got != want {
+			t.Fatalf("Denied() = %v, want = %v", got, want)
+		}
+	}
+
+	check(false)
+	writeFile(t, dir, "sub mallory\nsub alice\n")
+	now = now.Add(30 * time.Second)
+	check(false)
+	now = now.Add(30 * time.Second)
+	check(true)
+
+	// A broken file leaves the previous entries in place.
+	writeFile(t, dir, "sub\n")
+	now = now.Add(time.Minute)
+	check(true)
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// denySet is a DenyList of the tokens it contains.
+type denySet map[TokenID]bool
+
+func (d denySet) Denied(ctx context.Context, token TokenID) (bool, error) {
+	return d[token], nil
+}
+
+func TestParserDenyList(t *testing.T) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+	if err != nil {
+		t.Fatalf("Error creating signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{ID: "token-1"}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	alice := Identity{Issuer: "https://idp.example.com", Subject: "alice"}
+
+	tests := []struct {
+		name   string
+		auth   string
+		denied TokenID
+		want   error
+	}{
+		{name: "not denied", auth: token, denied: TokenID{ID: "token-2"}},
+		{name: "jti", auth: token, denied: TokenID{ID: "token-1", Issuer: alice.Issuer, Subject: alice.Subject}, want: ErrDenied},
+		{name: "opaque", auth: "opaque", denied: TokenID{Issuer: alice.Issuer, Subject: alice.Subject}, want: ErrDenied},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := NewParser(ctx, []Shim{&countingShim{identity: alice}}, nil)
+			if err != nil {
+				t.Fatalf("NewParser() = %v", err)
+			}
+			parser.DenyList = denySet{test.denied: true}
+			if _, err := parser.Parse(ctx, test.auth); err != test.want {
+				t.Fatalf("Parse() = %v, want = %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluatorDenyListWithCache(t *testing.T) {
+	ctx := context.Background()
+	alice := Identity{Issuer: "https://idp.example.com", Subject: "alice"}
+	ev, _ := newCachedEvaluator(t, NewDecisionCache(0, time.Hour), alice, true)
+	denied := denySet{}
+	ev.Parser.DenyList = denied
+
+	if _, err := ev.Evaluate(ctx, "opaque"); err != nil {
+		t.Fatalf("Evaluate() = %v", err)
+	}
+	denied[TokenID{Issuer: alice.Issuer, Subject: alice.Subject}] = true
+	if _, err := ev.Evaluate(ctx, "opaque"); err != ErrDenied {
+		t.Fatalf("Evaluate() of a cached token after it was denied = %v, want %v", err, ErrDenied)
+	}
+}
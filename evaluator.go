@@ -20,24 +20,84 @@ import (
 	"fmt"
 )
 
-// Evaluator combines both parsing and validation of authorization tokens.
+// IdentityEvaluator is implemented by types that parse and validate
+// authorization strings, such as *Evaluator.
+type IdentityEvaluator interface {
+	Evaluate(ctx context.Context, auth string) (*Identity, error)
+}
+
+// Evaluator combines both parsing and validation of authorization tokens.  It
+// also decides access to individual resources with its Authorizer, if any.
 type Evaluator struct {
-	Parser    *Parser
-	Validator Validator
+	Parser     *Parser
+	Validator  Validator
+	Authorizer Authorizer
+	// Enrichers are applied, in order, to each parsed identity before it is
+	// validated.
+	Enrichers []Enricher
+	// Cache, if set, holds the identities of recently evaluated tokens.
+	Cache *DecisionCache
 }
 
 // Evaluate attempts to parse auth using ev.Parser, and then validate it using
-// ev.Validator.  It will only return a non-error result if the identity both
-// parses and validates.
+// ev.Validator.  Before the identity is validated, any groups asserted by the
+// token are removed from it, ev.Enrichers are applied to it with auth
+// available through AuthorizationFromContext, and then visas whose conditions
+// are not met are removed from it.  It will only return a non-error result if
+// the identity both parses and validates.  ErrDenied is returned unchanged so
+// that callers can compare against it.
+// Identities returned from ev.Cache are shared between callers and must not be
+// modified.
 func (ev *Evaluator) Evaluate(ctx context.Context, auth string) (*Identity, error) {
 	if auth == "" {
 		return nil, errors.New("empty authorization")
 	}
+	if ev.Cache == nil {
+		return ev.evaluate(ctx, auth)
+	}
+
+	key := cacheKey(auth)
+	if id, ok := ev.Cache.get(key, Now(ctx)); ok {
+		// Tokens may be added to the deny list after they were cached.
+		if err := ev.Parser.checkDenyList(ctx, auth, id); err != nil {
+			return nil, err
+		}
+		return id, nil
+	}
+	id, err := ev.evaluate(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	ev.Cache.add(key, auth, id, Now(ctx))
+	return id, nil
+}
 
+func (ev *Evaluator) evaluate(ctx context.Context, auth string) (*Identity, error) {
 	id, err := ev.Parser.Parse(ctx, auth)
+	if err == ErrDenied {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("parsing authorization: %v", err)
 	}
+	if len(id.Groups) > 0 {
+		// Copy the identity, since parsers and shims may return shared values.
+		copied := *id
+		// Groups are only trusted when they are added by an enricher.
+		copied.Groups = nil
+		id = &copied
+	}
+	enrichCtx := NewAuthorizationContext(ctx, auth)
+	for i, e := range ev.Enrichers {
+		if id, err = e.Enrich(enrichCtx, id); err != nil {
+			return nil, fmt.Errorf("enriching identity with enricher %d: %v", i, err)
+		}
+	}
+	if len(id.Visas) > 0 {
+		copied := *id
+		copied.Visas = ActiveVisas(ctx, id.Visas)
+		id = &copied
+	}
 
 	ok, err := ev.Validator.Validate(ctx, id)
 	if err != nil {
@@ -49,3 +109,13 @@ func (ev *Evaluator) Evaluate(ctx context.Context, auth string) (*Identity, erro
 
 	return id, nil
 }
+
+// Authorize implements the Authorizer interface using ev.Authorizer.  An
+// identity must have been returned by Evaluate before it is authorized.  If ev
+// has no Authorizer then access to every resource is denied.
+func (ev *Evaluator) Authorize(ctx context.Context, identity *Identity, resource string) (bool, error) {
+	if ev.Authorizer == nil {
+		return false, errors.New("no authorizer configured")
+	}
+	return ev.Authorizer.Authorize(ctx, identity, resource)
+}
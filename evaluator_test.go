@@ -0,0 +1,90 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// groupEnricher adds a fixed group, or fails if err is set.
+type groupEnricher struct {
+	group string
+	err   error
+}
+
+func (e *groupEnricher) Enrich(ctx context.Context, identity *Identity) (*Identity, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	enriched := *identity
+	enriched.Groups = append(append([]StringValue(nil), identity.Groups...), StringValue{Value: e.group})
+	return &enriched, nil
+}
+
+func TestEvaluatorEnrichers(t *testing.T) {
+	ctx := context.Background()
+	// Groups asserted by the token itself must not be trusted.
+	ev, _ := newCachedEvaluator(t, nil, Identity{Groups: []StringValue{{Value: "forged"}}}, true)
+	ev.Enrichers = []Enricher{&groupEnricher{group: "a"}, &groupEnricher{group: "b"}}
+
+	id, err := ev.Evaluate(ctx, "token")
+	if err != nil {
+		t.Fatalf("Evaluate() = %v", err)
+	}
+	if want := []StringValue{{Value: "a"}, {Value: "b"}}; !reflect.DeepEqual(id.Groups, want) {
+		t.Fatalf("Evaluate() groups = %+v, want = %+v", id.Groups, want)
+	}
+
+	ev.Enrichers = append(ev.Enrichers, &groupEnricher{err: errors.New("unavailable")})
+	if _, err := ev.Evaluate(ctx, "token"); err == nil {
+		t.Fatal("Evaluate() with a failing enricher succeeded, want error")
+	}
+}
+
+// visaEnricher adds visas and records the authorization it was called with.
+type visaEnricher struct {
+	visas []Visa
+	auth  string
+}
+
+func (e *visaEnricher) Enrich(ctx context.Context, identity *Identity) (*Identity, error) {
+	e.auth, _ = AuthorizationFromContext(ctx)
+	enriched := *identity
+	enriched.Visas = append(append([]Visa(nil), identity.Visas...), e.visas...)
+	return &enriched, nil
+}
+
+func TestEvaluatorFiltersEnrichedVisas(t *testing.T) {
+	ctx := context.Background()
+	ev, _ := newCachedEvaluator(t, nil, Identity{}, true)
+	active := Visa{Type: "AffiliationAndRole", Value: "faculty@uni.example.org"}
+	inactive := Visa{Type: "ControlledAccessGrants", Value: "a", Conditions: [][]Condition{{{Type: "AcceptedTermsAndPolicies"}}}}
+	e := &visaEnricher{visas: []Visa{active, inactive}}
+	ev.Enrichers = []Enricher{e}
+
+	id, err := ev.Evaluate(ctx, "token")
+	if err != nil {
+		t.Fatalf("Evaluate() = %v", err)
+	}
+	if want := []Visa{active}; !reflect.DeepEqual(id.Visas, want) {
+		t.Fatalf("Evaluate() visas = %+v, want = %+v", id.Visas, want)
+	}
+	if e.auth != "token" {
+		t.Fatalf("enricher was called with authorization %q, want %q", e.auth, "token")
+	}
+}
@@ -0,0 +1,746 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: config.proto
+
+package server
+
+/*
+Package server contains the configuration shared by the Google Cloud
+Platform daemons.  A Config is usually stored as a text proto and passed to
+a daemon with the -config flag.
+*/
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import builder "github.com/googlegenomics/ga4gh-identity/builder"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Config struct {
+	// Configures the parsing and validation of incoming identities.
+	Evaluator  *builder.Evaluator `protobuf:"bytes,1,opt,name=evaluator,proto3" json:"evaluator,omitempty"`
+	Warehouse  *Warehouse         `protobuf:"bytes,2,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	Listener   *Listener          `protobuf:"bytes,3,opt,name=listener,proto3" json:"listener,omitempty"`
+	RateLimits *RateLimits        `protobuf:"bytes,4,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	// The URL that the proxy forwards requests to when no routes are
+	// configured.  Unused by the key-vendor.
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	// Routes used by the proxy to select a target for each request.  The first
+	// route that matches a request is used, and requests matching no route are
+	// rejected.  Unused by the key-vendor.
+	Routes []*Route `protobuf:"bytes,6,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Identity headers added to requests forwarded by the proxy.  Unused by the
+	// key-vendor.
+	IdentityHeaders *IdentityHeaders `protobuf:"bytes,7,opt,name=identity_headers,json=identityHeaders,proto3" json:"identity_headers,omitempty"`
+	// A text proto, JSON or YAML file containing the evaluator, used instead of
+	// evaluator.  The file is checked for changes every reload_interval and a
+	// changed configuration replaces the active one if it lints cleanly and
+	// builds successfully.
+	EvaluatorFile string `protobuf:"bytes,8,opt,name=evaluator_file,json=evaluatorFile,proto3" json:"evaluator_file,omitempty"`
+	// How often evaluator_file is checked for changes, in the format accepted by
+	// time.ParseDuration.  Defaults to 30s.
+	ReloadInterval       string   `protobuf:"bytes,9,opt,name=reload_interval,json=reloadInterval,proto3" json:"reload_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{0}
+}
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (dst *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(dst, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetEvaluator() *builder.Evaluator {
+	if m != nil {
+		return m.Evaluator
+	}
+	return nil
+}
+
+func (m *Config) GetWarehouse() *Warehouse {
+	if m != nil {
+		return m.Warehouse
+	}
+	return nil
+}
+
+func (m *Config) GetListener() *Listener {
+	if m != nil {
+		return m.Listener
+	}
+	return nil
+}
+
+func (m *Config) GetRateLimits() *RateLimits {
+	if m != nil {
+		return m.RateLimits
+	}
+	return nil
+}
+
+func (m *Config) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *Config) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+func (m *Config) GetIdentityHeaders() *IdentityHeaders {
+	if m != nil {
+		return m.IdentityHeaders
+	}
+	return nil
+}
+
+func (m *Config) GetEvaluatorFile() string {
+	if m != nil {
+		return m.EvaluatorFile
+	}
+	return ""
+}
+
+func (m *Config) GetReloadInterval() string {
+	if m != nil {
+		return m.ReloadInterval
+	}
+	return ""
+}
+
+type Route struct {
+	// The host that requests must be addressed to, ignoring any port.  If empty,
+	// requests to any host match.
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// The prefix that escaped request paths must start with, matching whole
+	// path segments unless it ends with "/".  If empty, all paths match.
+	PathPrefix string `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// The URL that matching requests are forwarded to.
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// The OAuth 2.0 scopes granted to access tokens for this route.  If empty,
+	// the warehouse scopes are used.
+	Scopes []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Configures the parsing and validation of identities for this route.  If
+	// unset, the top-level evaluator is used.
+	Evaluator *builder.Evaluator `protobuf:"bytes,5,opt,name=evaluator,proto3" json:"evaluator,omitempty"`
+	// If set, path_prefix is replaced with this value before forwarding.
+	RewritePrefix string `protobuf:"bytes,6,opt,name=rewrite_prefix,json=rewritePrefix,proto3" json:"rewrite_prefix,omitempty"`
+	// Additional authorization policies for requests on this route.  The first
+	// policy matching a request must validate its identity, otherwise the
+	// request is rejected.  Requests matching no policy only need to pass the
+	// route's evaluator.  If any policies are set, requests on this route
+	// without a bearer token that passes the route's evaluator are rejected.
+	Policies []*Policy `protobuf:"bytes,7,rep,name=policies,proto3" json:"policies,omitempty"`
+	// Identity headers added to requests on this route.  If unset, the top-level
+	// identity_headers are used.
+	IdentityHeaders      *IdentityHeaders `protobuf:"bytes,8,opt,name=identity_headers,json=identityHeaders,proto3" json:"identity_headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Route) Reset()         { *m = Route{} }
+func (m *Route) String() string { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()    {}
+func (*Route) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{1}
+}
+func (m *Route) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Route.Unmarshal(m, b)
+}
+func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Route.Marshal(b, m, deterministic)
+}
+func (dst *Route) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Route.Merge(dst, src)
+}
+func (m *Route) XXX_Size() int {
+	return xxx_messageInfo_Route.Size(m)
+}
+func (m *Route) XXX_DiscardUnknown() {
+	xxx_messageInfo_Route.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Route proto.InternalMessageInfo
+
+func (m *Route) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Route) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
+
+func (m *Route) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *Route) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *Route) GetEvaluator() *builder.Evaluator {
+	if m != nil {
+		return m.Evaluator
+	}
+	return nil
+}
+
+func (m *Route) GetRewritePrefix() string {
+	if m != nil {
+		return m.RewritePrefix
+	}
+	return ""
+}
+
+func (m *Route) GetPolicies() []*Policy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+func (m *Route) GetIdentityHeaders() *IdentityHeaders {
+	if m != nil {
+		return m.IdentityHeaders
+	}
+	return nil
+}
+
+type Policy struct {
+	// The HTTP methods this policy applies to.  If empty, all methods match.
+	Methods []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	// The pattern that request paths, before any rewrite, must match.  A "*"
+	// matches any sequence of characters other than "/", while "**" matches any
+	// sequence of characters.  If empty, all paths match.  For example,
+	// "/storage/v1/b/cohort-a/**" matches all objects in the cohort-a bucket.
+	// Paths are matched in their escaped form, with each segment escaped as by
+	// url.PathEscape, so an escaped "/" within a segment appears as "%2F".
+	// Requests whose paths contain empty, "." or ".." segments are rejected.
+	PathPattern string `protobuf:"bytes,2,opt,name=path_pattern,json=pathPattern,proto3" json:"path_pattern,omitempty"`
+	// The validator that identities must pass.  If unset, and dataset is also
+	// unset, all requests matching this policy are rejected.
+	Validator *builder.Validator `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
+	// A dataset ID that identities must be authorized for by the datasets of
+	// the route evaluator, in addition to passing validator if it is set.
+	Dataset              string   `protobuf:"bytes,4,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Policy) Reset()         { *m = Policy{} }
+func (m *Policy) String() string { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()    {}
+func (*Policy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{2}
+}
+func (m *Policy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Policy.Unmarshal(m, b)
+}
+func (m *Policy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Policy.Marshal(b, m, deterministic)
+}
+func (dst *Policy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Policy.Merge(dst, src)
+}
+func (m *Policy) XXX_Size() int {
+	return xxx_messageInfo_Policy.Size(m)
+}
+func (m *Policy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Policy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Policy proto.InternalMessageInfo
+
+func (m *Policy) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *Policy) GetPathPattern() string {
+	if m != nil {
+		return m.PathPattern
+	}
+	return ""
+}
+
+func (m *Policy) GetValidator() *builder.Validator {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *Policy) GetDataset() string {
+	if m != nil {
+		return m.Dataset
+	}
+	return ""
+}
+
+type Warehouse struct {
+	// The project that backing service accounts are created in.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// The role assigned to backing service accounts as they are created.
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// The OAuth 2.0 scopes granted to generated access tokens.
+	Scopes               []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Warehouse) Reset()         { *m = Warehouse{} }
+func (m *Warehouse) String() string { return proto.CompactTextString(m) }
+func (*Warehouse) ProtoMessage()    {}
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{3}
+}
+func (m *Warehouse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Warehouse.Unmarshal(m, b)
+}
+func (m *Warehouse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Warehouse.Marshal(b, m, deterministic)
+}
+func (dst *Warehouse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Warehouse.Merge(dst, src)
+}
+func (m *Warehouse) XXX_Size() int {
+	return xxx_messageInfo_Warehouse.Size(m)
+}
+func (m *Warehouse) XXX_DiscardUnknown() {
+	xxx_messageInfo_Warehouse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Warehouse proto.InternalMessageInfo
+
+func (m *Warehouse) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *Warehouse) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Warehouse) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+type Listener struct {
+	// The address to listen on, for example ":8080".
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// If both are set, the listener serves HTTPS using this certificate and key.
+	TlsCertFile string `protobuf:"bytes,2,opt,name=tls_cert_file,json=tlsCertFile,proto3" json:"tls_cert_file,omitempty"`
+	TlsKeyFile  string `protobuf:"bytes,3,opt,name=tls_key_file,json=tlsKeyFile,proto3" json:"tls_key_file,omitempty"`
+	// How long in-flight requests are given to complete after a shutdown
+	// signal, in the format accepted by time.ParseDuration.  Defaults to 10s.
+	ShutdownTimeout      string   `protobuf:"bytes,4,opt,name=shutdown_timeout,json=shutdownTimeout,proto3" json:"shutdown_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Listener) Reset()         { *m = Listener{} }
+func (m *Listener) String() string { return proto.CompactTextString(m) }
+func (*Listener) ProtoMessage()    {}
+func (*Listener) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{4}
+}
+func (m *Listener) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Listener.Unmarshal(m, b)
+}
+func (m *Listener) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Listener.Marshal(b, m, deterministic)
+}
+func (dst *Listener) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Listener.Merge(dst, src)
+}
+func (m *Listener) XXX_Size() int {
+	return xxx_messageInfo_Listener.Size(m)
+}
+func (m *Listener) XXX_DiscardUnknown() {
+	xxx_messageInfo_Listener.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Listener proto.InternalMessageInfo
+
+func (m *Listener) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Listener) GetTlsCertFile() string {
+	if m != nil {
+		return m.TlsCertFile
+	}
+	return ""
+}
+
+func (m *Listener) GetTlsKeyFile() string {
+	if m != nil {
+		return m.TlsKeyFile
+	}
+	return ""
+}
+
+func (m *Listener) GetShutdownTimeout() string {
+	if m != nil {
+		return m.ShutdownTimeout
+	}
+	return ""
+}
+
+type RateLimits struct {
+	// Rates are written as count/window, for example "10/1m".
+	SubjectRate          string   `protobuf:"bytes,1,opt,name=subject_rate,json=subjectRate,proto3" json:"subject_rate,omitempty"`
+	GlobalRate           string   `protobuf:"bytes,2,opt,name=global_rate,json=globalRate,proto3" json:"global_rate,omitempty"`
+	SubjectDailyQuota    int64    `protobuf:"varint,3,opt,name=subject_daily_quota,json=subjectDailyQuota,proto3" json:"subject_daily_quota,omitempty"`
+	GlobalDailyQuota     int64    `protobuf:"varint,4,opt,name=global_daily_quota,json=globalDailyQuota,proto3" json:"global_daily_quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimits) Reset()         { *m = RateLimits{} }
+func (m *RateLimits) String() string { return proto.CompactTextString(m) }
+func (*RateLimits) ProtoMessage()    {}
+func (*RateLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{5}
+}
+func (m *RateLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimits.Unmarshal(m, b)
+}
+func (m *RateLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimits.Marshal(b, m, deterministic)
+}
+func (dst *RateLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimits.Merge(dst, src)
+}
+func (m *RateLimits) XXX_Size() int {
+	return xxx_messageInfo_RateLimits.Size(m)
+}
+func (m *RateLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimits proto.InternalMessageInfo
+
+func (m *RateLimits) GetSubjectRate() string {
+	if m != nil {
+		return m.SubjectRate
+	}
+	return ""
+}
+
+func (m *RateLimits) GetGlobalRate() string {
+	if m != nil {
+		return m.GlobalRate
+	}
+	return ""
+}
+
+func (m *RateLimits) GetSubjectDailyQuota() int64 {
+	if m != nil {
+		return m.SubjectDailyQuota
+	}
+	return 0
+}
+
+func (m *RateLimits) GetGlobalDailyQuota() int64 {
+	if m != nil {
+		return m.GlobalDailyQuota
+	}
+	return 0
+}
+
+// IdentityHeaders describes how the verified identity of a researcher is
+// passed to services behind the proxy.  Every header named here is removed
+// from incoming requests, whether or not their identity verifies, so that
+// clients cannot supply their own.
+type IdentityHeaders struct {
+	// The header that carries the identity's issuer.
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// The header that carries the identity's subject.
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Maps ga4gh.Identity field names, such as "Role", to the header that
+	// carries their values.  Each value is escaped as by url.QueryEscape, so
+	// "PI, Lab A" is sent as "PI%2C+Lab+A", and multiple values are separated
+	// by commas.
+	Claims map[string]string `protobuf:"bytes,3,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// A signed assertion carrying the whole identity.
+	Assertion            *Assertion `protobuf:"bytes,4,opt,name=assertion,proto3" json:"assertion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *IdentityHeaders) Reset()         { *m = IdentityHeaders{} }
+func (m *IdentityHeaders) String() string { return proto.CompactTextString(m) }
+func (*IdentityHeaders) ProtoMessage()    {}
+func (*IdentityHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{6}
+}
+func (m *IdentityHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentityHeaders.Unmarshal(m, b)
+}
+func (m *IdentityHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentityHeaders.Marshal(b, m, deterministic)
+}
+func (dst *IdentityHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityHeaders.Merge(dst, src)
+}
+func (m *IdentityHeaders) XXX_Size() int {
+	return xxx_messageInfo_IdentityHeaders.Size(m)
+}
+func (m *IdentityHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityHeaders.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentityHeaders proto.InternalMessageInfo
+
+func (m *IdentityHeaders) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *IdentityHeaders) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *IdentityHeaders) GetClaims() map[string]string {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+func (m *IdentityHeaders) GetAssertion() *Assertion {
+	if m != nil {
+		return m.Assertion
+	}
+	return nil
+}
+
+// Assertion configures a JWT, signed by the proxy, that asserts the verified
+// identity to upstream services.
+type Assertion struct {
+	// The header that carries the assertion.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// A PEM encoded RSA or ECDSA private key used to sign the assertion.
+	KeyFile string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// The "iss" claim of the assertion.
+	Issuer string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// The "aud" claim of the assertion.
+	Audience string `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	// How long the assertion is valid for, in the format accepted by
+	// time.ParseDuration.  Defaults to 5m.
+	Lifetime             string   `protobuf:"bytes,5,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Assertion) Reset()         { *m = Assertion{} }
+func (m *Assertion) String() string { return proto.CompactTextString(m) }
+func (*Assertion) ProtoMessage()    {}
+func (*Assertion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4ae6e6453b5b8b9f, []int{7}
+}
+func (m *Assertion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Assertion.Unmarshal(m, b)
+}
+func (m *Assertion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Assertion.Marshal(b, m, deterministic)
+}
+func (dst *Assertion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Assertion.Merge(dst, src)
+}
+func (m *Assertion) XXX_Size() int {
+	return xxx_messageInfo_Assertion.Size(m)
+}
+func (m *Assertion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Assertion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Assertion proto.InternalMessageInfo
+
+func (m *Assertion) GetHeader() string {
+	if m != nil {
+		return m.Header
+	}
+	return ""
+}
+
+func (m *Assertion) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+func (m *Assertion) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *Assertion) GetAudience() string {
+	if m != nil {
+		return m.Audience
+	}
+	return ""
+}
+
+func (m *Assertion) GetLifetime() string {
+	if m != nil {
+		return m.Lifetime
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "server.Config")
+	proto.RegisterType((*Route)(nil), "server.Route")
+	proto.RegisterType((*Policy)(nil), "server.Policy")
+	proto.RegisterType((*Warehouse)(nil), "server.Warehouse")
+	proto.RegisterType((*Listener)(nil), "server.Listener")
+	proto.RegisterType((*RateLimits)(nil), "server.RateLimits")
+	proto.RegisterType((*IdentityHeaders)(nil), "server.IdentityHeaders")
+	proto.RegisterMapType((map[string]string)(nil), "server.IdentityHeaders.ClaimsEntry")
+	proto.RegisterType((*Assertion)(nil), "server.Assertion")
+}
+
+func init() { proto.RegisterFile("config.proto", fileDescriptor_config_4ae6e6453b5b8b9f) }
+
+var fileDescriptor_config_4ae6e6453b5b8b9f = []byte{
+	// 794 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x8e, 0x23, 0x35,
+	0x10, 0x56, 0x4f, 0x27, 0xbd, 0xe9, 0xca, 0xe4, 0x67, 0x0d, 0x5a, 0x9a, 0xb9, 0x10, 0x1a, 0x8d,
+	0x18, 0xd0, 0x2a, 0xa0, 0xd9, 0x0b, 0x3f, 0x27, 0x18, 0x16, 0xb1, 0x62, 0x0f, 0xb3, 0x16, 0x82,
+	0x63, 0xcb, 0x49, 0x57, 0x26, 0x66, 0x9c, 0x76, 0xb0, 0xdd, 0x19, 0xf2, 0x12, 0x48, 0x1c, 0x78,
+	0x00, 0x5e, 0x80, 0x1b, 0x6f, 0xc5, 0x43, 0x20, 0xff, 0x75, 0x92, 0x15, 0x48, 0x70, 0xeb, 0xfa,
+	0xea, 0x73, 0xb9, 0xea, 0xab, 0xcf, 0x09, 0x9c, 0x2f, 0x65, 0xb3, 0xe2, 0x77, 0xf3, 0xad, 0x92,
+	0x46, 0x92, 0x4c, 0xa3, 0xda, 0xa1, 0xba, 0x18, 0x2d, 0x5a, 0x2e, 0x6a, 0x54, 0x1e, 0x2e, 0x7f,
+	0x4f, 0x21, 0xbb, 0x71, 0x3c, 0xf2, 0x31, 0xe4, 0xb8, 0x63, 0xa2, 0x65, 0x46, 0xaa, 0x22, 0x99,
+	0x25, 0x57, 0xc3, 0x6b, 0x32, 0x8f, 0xec, 0xe7, 0x31, 0x43, 0x0f, 0x24, 0xf2, 0x11, 0xe4, 0x0f,
+	0x4c, 0xe1, 0x5a, 0xb6, 0x1a, 0x8b, 0x33, 0x77, 0xe2, 0xf1, 0xdc, 0xdf, 0x33, 0xff, 0x21, 0x26,
+	0xe8, 0x81, 0x43, 0x9e, 0xc2, 0x40, 0x70, 0x6d, 0xb0, 0x41, 0x55, 0xa4, 0x8e, 0x3f, 0x8d, 0xfc,
+	0x97, 0x01, 0xa7, 0x1d, 0x83, 0x3c, 0x83, 0xa1, 0x62, 0x06, 0x2b, 0xc1, 0x37, 0xdc, 0xe8, 0xa2,
+	0x17, 0x5a, 0x0a, 0x07, 0x28, 0x33, 0xf8, 0xd2, 0x65, 0x28, 0xa8, 0xee, 0x9b, 0x3c, 0x81, 0xcc,
+	0x30, 0x75, 0x87, 0xa6, 0xe8, 0xcf, 0x92, 0xab, 0x9c, 0x86, 0x88, 0x5c, 0x42, 0xa6, 0x64, 0x6b,
+	0x50, 0x17, 0xd9, 0x2c, 0xbd, 0x1a, 0x5e, 0x8f, 0xba, 0x3a, 0x16, 0xa5, 0x21, 0x49, 0xbe, 0x84,
+	0x29, 0xaf, 0xb1, 0x31, 0xdc, 0xec, 0xab, 0x35, 0xb2, 0x1a, 0x95, 0x2e, 0x1e, 0xb9, 0x8b, 0xdf,
+	0x8a, 0x07, 0x5e, 0x84, 0xfc, 0x37, 0x3e, 0x4d, 0x27, 0xfc, 0x14, 0x20, 0x97, 0x30, 0xee, 0x34,
+	0xaa, 0x56, 0x5c, 0x60, 0x31, 0x70, 0xad, 0x8c, 0x3a, 0xf4, 0x6b, 0x2e, 0x90, 0xbc, 0x0f, 0x13,
+	0x85, 0x42, 0xb2, 0xba, 0xe2, 0x8d, 0x41, 0xb5, 0x63, 0xa2, 0xc8, 0x1d, 0x6f, 0xec, 0xe1, 0x17,
+	0x01, 0x2d, 0xff, 0x3c, 0x83, 0xbe, 0xeb, 0x92, 0x10, 0xe8, 0xad, 0xa5, 0x36, 0x6e, 0x3b, 0x39,
+	0x75, 0xdf, 0xe4, 0x1d, 0x18, 0x6e, 0x99, 0x59, 0x57, 0x5b, 0x85, 0x2b, 0xfe, 0xb3, 0x5b, 0x43,
+	0x4e, 0xc1, 0x42, 0xb7, 0x0e, 0x39, 0x52, 0x24, 0x3d, 0x51, 0xe4, 0x09, 0x64, 0x7a, 0x29, 0xb7,
+	0x68, 0x95, 0x4d, 0x2d, 0xee, 0xa3, 0x53, 0x1f, 0xf4, 0xff, 0x8b, 0x0f, 0x2e, 0x61, 0xac, 0xf0,
+	0x41, 0x71, 0x83, 0xb1, 0x8b, 0xcc, 0x0f, 0x1c, 0xd0, 0xd0, 0xc8, 0x87, 0x30, 0xd8, 0x4a, 0xc1,
+	0x97, 0x1c, 0xad, 0xa6, 0x76, 0x09, 0xe3, 0xa8, 0xe9, 0xad, 0xc5, 0xf7, 0xb4, 0xcb, 0xff, 0xe3,
+	0x1e, 0x06, 0xff, 0x6f, 0x0f, 0xe5, 0xaf, 0x09, 0x64, 0xbe, 0x30, 0x29, 0xe0, 0xd1, 0x06, 0xcd,
+	0x5a, 0xd6, 0xba, 0x48, 0xdc, 0xb0, 0x31, 0x24, 0xef, 0xc2, 0xb9, 0x97, 0x8f, 0x19, 0x83, 0xaa,
+	0x09, 0xfa, 0x39, 0x49, 0x6f, 0x3d, 0x64, 0x05, 0xd9, 0x31, 0xc1, 0x6b, 0x27, 0x48, 0xfa, 0x9a,
+	0x20, 0xdf, 0xc7, 0x0c, 0x3d, 0x90, 0xec, 0x75, 0x35, 0x33, 0x4c, 0xa3, 0x71, 0xae, 0xcd, 0x69,
+	0x0c, 0xcb, 0x57, 0x90, 0x77, 0x2f, 0xc3, 0xd2, 0xb6, 0x4a, 0xfe, 0x88, 0xcb, 0xb8, 0xd1, 0x18,
+	0xda, 0x45, 0x2b, 0x29, 0x30, 0x74, 0xe3, 0xbe, 0x8f, 0xf6, 0x95, 0x1e, 0xef, 0xab, 0xfc, 0x2d,
+	0x81, 0x41, 0x7c, 0x3d, 0xb6, 0x24, 0xab, 0x6b, 0x85, 0x5a, 0xc7, 0x92, 0x21, 0x24, 0x25, 0x8c,
+	0x8c, 0xd0, 0xd5, 0x12, 0x95, 0xf1, 0xa6, 0x0c, 0x93, 0x1a, 0xa1, 0x6f, 0x50, 0x19, 0x67, 0xc9,
+	0x19, 0x9c, 0x5b, 0xce, 0x3d, 0xee, 0x3d, 0xc5, 0x1b, 0x06, 0x8c, 0xd0, 0xdf, 0xe2, 0xde, 0x31,
+	0x3e, 0x80, 0xa9, 0x5e, 0xb7, 0xa6, 0x96, 0x0f, 0x4d, 0x65, 0xf8, 0x06, 0x65, 0x1b, 0x47, 0x9c,
+	0x44, 0xfc, 0x3b, 0x0f, 0x97, 0x7f, 0x24, 0x00, 0x87, 0x47, 0x6a, 0x85, 0xd6, 0xed, 0xc2, 0x4e,
+	0x57, 0xd9, 0xe7, 0x1a, 0xda, 0x1b, 0x06, 0xcc, 0x12, 0xad, 0x95, 0xef, 0x84, 0x5c, 0x30, 0xe1,
+	0x19, 0xc1, 0xca, 0x1e, 0x72, 0x84, 0x39, 0xbc, 0x11, 0x6b, 0xd4, 0x8c, 0x8b, 0x7d, 0xf5, 0x53,
+	0x2b, 0x0d, 0x73, 0x6d, 0xa6, 0xf4, 0x71, 0x48, 0x7d, 0x65, 0x33, 0xaf, 0x6c, 0x82, 0x3c, 0x05,
+	0x12, 0x0a, 0x1e, 0xd3, 0x7b, 0x8e, 0x3e, 0xf5, 0x99, 0x03, 0xbb, 0xfc, 0x2b, 0x81, 0xc9, 0x6b,
+	0xa6, 0xb2, 0xa2, 0x73, 0xad, 0x5b, 0x54, 0xa1, 0xdf, 0x10, 0x59, 0x9d, 0xc3, 0x75, 0xa1, 0xcd,
+	0x18, 0x92, 0xcf, 0x21, 0x5b, 0x0a, 0xc6, 0x37, 0x7e, 0x4d, 0xc3, 0xeb, 0xf7, 0xfe, 0xc5, 0xaf,
+	0xf3, 0x1b, 0xc7, 0x7a, 0xde, 0x18, 0xb5, 0xa7, 0xe1, 0x88, 0xfd, 0x45, 0x65, 0x5a, 0xa3, 0x32,
+	0x5c, 0x36, 0x45, 0xef, 0xf4, 0x17, 0xf5, 0x8b, 0x98, 0xa0, 0x07, 0xce, 0xc5, 0xa7, 0x30, 0x3c,
+	0xaa, 0x43, 0xa6, 0x90, 0xde, 0xe3, 0x3e, 0xf4, 0x6a, 0x3f, 0xc9, 0x9b, 0xd0, 0xb7, 0xef, 0x34,
+	0xaa, 0xe9, 0x83, 0xcf, 0xce, 0x3e, 0x49, 0xca, 0x5f, 0x12, 0xc8, 0xbb, 0x9a, 0x76, 0x50, 0xff,
+	0xce, 0xe2, 0xa0, 0x3e, 0x22, 0x6f, 0xc3, 0xa0, 0xb3, 0x43, 0x98, 0xf4, 0x3e, 0x78, 0xe1, 0xa0,
+	0x4d, 0x7a, 0xa2, 0xcd, 0x05, 0x0c, 0x58, 0x5b, 0x73, 0x6c, 0x96, 0x18, 0xbc, 0xd1, 0xc5, 0x36,
+	0x27, 0xf8, 0x0a, 0xad, 0x75, 0xc2, 0x0f, 0x74, 0x17, 0x2f, 0x32, 0xf7, 0x97, 0xf4, 0xec, 0xef,
+	0x01, 0x00, 0xad, 0x08, 0xe9, 0x71, 0xb9, 0x06, 0x00, 0x00,
+}
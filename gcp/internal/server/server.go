@@ -0,0 +1,304 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server provides common functionality for the Google Cloud Platform
+// daemons, such as loading their configuration and serving HTTP.  The
+// daemons can be configured either with a Config file passed via the -config
+// flag, or with the environment variables described in their
+// app.yaml files when running on App Engine.
+package server
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/builder"
+	"github.com/googlegenomics/ga4gh-identity/gcp"
+	"github.com/googlegenomics/ga4gh-identity/gcp/ratelimit"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	defaultShutdownTimeout = 10 * time.Second
+	defaultReloadInterval  = 30 * time.Second
+)
+
+var (
+	configFile = flag.String("config", "", "path to a server.Config as a text proto, JSON or YAML file; if unset, configuration is read from the environment")
+	addr       = flag.String("addr", "", "address to listen on, overriding the configuration")
+	tlsCert    = flag.String("tls_cert", "", "TLS certificate file, overriding the configuration")
+	tlsKey     = flag.String("tls_key", "", "TLS key file, overriding the configuration")
+)
+
+// MustLoadConfig loads the Config named by the -config flag, or builds one from
+// the environment if the flag is unset, and applies any overriding flags.
+// flag.Parse must be called first.  It panics on failure.
+func MustLoadConfig() *Config {
+	var cfg *Config
+	var err error
+	if *configFile != "" {
+		cfg, err = LoadConfig(*configFile)
+	} else {
+		cfg, err = configFromEnv()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+		return nil
+	}
+
+	if cfg.Listener == nil {
+		cfg.Listener = &Listener{}
+	}
+	if *addr != "" {
+		cfg.Listener.Address = *addr
+	}
+	if *tlsCert != "" {
+		cfg.Listener.TlsCertFile = *tlsCert
+	}
+	if *tlsKey != "" {
+		cfg.Listener.TlsKeyFile = *tlsKey
+	}
+	return cfg
+}
+
+// LoadConfig reads a Config from path, which may be a text proto, JSON or YAML
+// file as indicated by its extension.
+func LoadConfig(path string) (*Config, error) {
+	var cfg Config
+	if err := builder.LoadMessage(path, &cfg); err != nil {
+		return nil, fmt.Errorf("loading config: %v", err)
+	}
+	return &cfg, nil
+}
+
+// configFromEnv builds a Config from the environment variables used by the
+// App Engine deployments.  See the app.yaml files for their descriptions.
+func configFromEnv() (*Config, error) {
+	format := builder.Text
+	switch f := strings.ToLower(os.Getenv("EVALUATOR_FORMAT")); f {
+	case "", "text":
+	case "json":
+		format = builder.JSON
+	case "yaml":
+		format = builder.YAML
+	default:
+		return nil, fmt.Errorf("unknown EVALUATOR_FORMAT %q", f)
+	}
+	cfg := &Config{
+		EvaluatorFile: os.Getenv("EVALUATOR_FILE"),
+		Warehouse: &Warehouse{
+			Project: os.Getenv("PROJECT"),
+			Role:    os.Getenv("ROLE"),
+		},
+		Listener: &Listener{},
+		RateLimits: &RateLimits{
+			SubjectRate: os.Getenv("SUBJECT_RATE"),
+			GlobalRate:  os.Getenv("GLOBAL_RATE"),
+		},
+		Target: os.Getenv("TARGET"),
+	}
+	if cfg.EvaluatorFile == "" {
+		text := os.Getenv("EVALUATOR")
+		if strings.TrimSpace(text) == "" {
+			return nil, fmt.Errorf("one of EVALUATOR or EVALUATOR_FILE must be set")
+		}
+		var e builder.Evaluator
+		if err := builder.Unmarshal([]byte(text), format, &e); err != nil {
+			return nil, fmt.Errorf("parsing EVALUATOR: %v", err)
+		}
+		cfg.Evaluator = &e
+	}
+	if scopes := os.Getenv("SCOPES"); scopes != "" {
+		cfg.Warehouse.Scopes = strings.Split(scopes, ",")
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		cfg.Listener.Address = ":" + port
+	}
+	for key, quota := range map[string]*int64{
+		"SUBJECT_DAILY_QUOTA": &cfg.RateLimits.SubjectDailyQuota,
+		"GLOBAL_DAILY_QUOTA":  &cfg.RateLimits.GlobalDailyQuota,
+	} {
+		v := os.Getenv(key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s=%q must be a non-negative integer", key, v)
+		}
+		*quota = n
+	}
+	return cfg, nil
+}
+
+// BuildEvaluator constructs the evaluator described by cfg.  If
+// cfg.EvaluatorFile is set the returned evaluator is reloaded from that file
+// in the background whenever it changes, until ctx is done.  Configurations
+// with lint errors are rejected.  If neither it nor cfg.Evaluator is set then
+// BuildEvaluator returns nil.
+func BuildEvaluator(ctx context.Context, cfg *Config) (ga4gh.IdentityEvaluator, error) {
+	if cfg.EvaluatorFile == "" {
+		if cfg.Evaluator == nil {
+			return nil, nil
+		}
+		if err := builder.CheckLint(cfg.Evaluator); err != nil {
+			return nil, err
+		}
+		return builder.Build(ctx, cfg.Evaluator)
+	}
+
+	interval := defaultReloadInterval
+	if cfg.ReloadInterval != "" {
+		var err error
+		if interval, err = time.ParseDuration(cfg.ReloadInterval); err != nil {
+			return nil, fmt.Errorf("parsing reload interval: %v", err)
+		}
+	}
+	h, err := builder.NewHolder(ctx, &builder.FileSource{Path: cfg.EvaluatorFile}, &builder.HolderOptions{
+		OnReload: func(version string, err error) {
+			if err != nil {
+				log.Printf("Error reloading evaluator, keeping version %s: %v", version, err)
+				return
+			}
+			log.Printf("Reloaded evaluator, now using version %s", version)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Loaded evaluator version %s from %q", h.Version(), cfg.EvaluatorFile)
+	go h.Watch(ctx, interval)
+	return h, nil
+}
+
+// MustBuildEvaluator constructs the evaluator described by cfg, as
+// BuildEvaluator does.  It panics on failure or if no evaluator is configured.
+func MustBuildEvaluator(ctx context.Context, cfg *Config) ga4gh.IdentityEvaluator {
+	ev, err := BuildEvaluator(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to build evaluator: %v", err)
+		return nil
+	}
+	if ev == nil {
+		log.Fatalf("Configuration is missing an evaluator")
+		return nil
+	}
+	return ev
+}
+
+// MustBuildAccountWarehouse builds a *gcp.AccountWarehouse from cfg.Warehouse.
+// It panics on failure.
+func MustBuildAccountWarehouse(ctx context.Context, cfg *Config) *gcp.AccountWarehouse {
+	w := cfg.Warehouse
+	if w.GetProject() == "" || w.GetRole() == "" {
+		log.Fatalf("Configuration must specify a warehouse project and role")
+		return nil
+	}
+
+	client, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		log.Fatalf("Error creating HTTP client: %v", err)
+		return nil
+	}
+
+	wh, err := gcp.NewAccountWarehouse(client, &gcp.AccountWarehouseOptions{
+		Project:     w.Project,
+		DefaultRole: w.Role,
+		Scopes:      w.Scopes,
+	})
+	if err != nil {
+		log.Fatalf("Error creating account warehouse: %v", err)
+		return nil
+	}
+	return wh
+}
+
+// MustBuildLimiter builds a *ratelimit.Limiter from cfg.RateLimits.  Unset
+// limits are not enforced.  It panics on failure.
+func MustBuildLimiter(cfg *Config) *ratelimit.Limiter {
+	rl := cfg.RateLimits
+	subject, err := ratelimit.ParseRate(rl.GetSubjectRate())
+	if err != nil {
+		log.Fatalf("Error parsing subject rate: %v", err)
+		return nil
+	}
+	global, err := ratelimit.ParseRate(rl.GetGlobalRate())
+	if err != nil {
+		log.Fatalf("Error parsing global rate: %v", err)
+		return nil
+	}
+	return ratelimit.NewLimiter(&ratelimit.Options{
+		SubjectRate:       subject,
+		GlobalRate:        global,
+		SubjectDailyQuota: rl.GetSubjectDailyQuota(),
+		GlobalDailyQuota:  rl.GetGlobalDailyQuota(),
+	})
+}
+
+// ListenAndServe serves handler on the listener described by cfg.Listener,
+// using TLS if a certificate and key are configured.  On SIGINT or SIGTERM the
+// server stops accepting connections and waits for in-flight requests to
+// complete before returning.
+func ListenAndServe(cfg *Config, handler http.Handler) error {
+	l := cfg.Listener
+	timeout := defaultShutdownTimeout
+	if l.GetShutdownTimeout() != "" {
+		var err error
+		if timeout, err = time.ParseDuration(l.ShutdownTimeout); err != nil {
+			return fmt.Errorf("parsing shutdown timeout: %v", err)
+		}
+	}
+	if (l.GetTlsCertFile() == "") != (l.GetTlsKeyFile() == "") {
+		return errors.New("both a TLS certificate and key must be provided")
+	}
+
+	srv := &http.Server{
+		Addr:    l.GetAddress(),
+		Handler: handler,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		sig := <-signals
+		log.Printf("Received %v, shutting down", sig)
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		done <- srv.Shutdown(ctx)
+	}()
+
+	var err error
+	if l.GetTlsCertFile() != "" {
+		err = srv.ListenAndServeTLS(l.TlsCertFile, l.TlsKeyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
+		return err
+	}
+	return <-done
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadExampleConfigs(t *testing.T) {
+	for _, path := range []string{
+		"../../proxy/config.textproto",
+		"../../key-vendor/config.textproto",
+	} {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig(%q) = %v", path, err)
+		}
+		if cfg.GetEvaluator().GetParser() == nil || cfg.GetWarehouse().GetProject() == "" {
+			t.Fatalf("LoadConfig(%q) = %v, missing evaluator or warehouse", path, cfg)
+		}
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"EVALUATOR":           "validator { constant { value: true } }",
+		"PROJECT":             "project",
+		"ROLE":                "roles/Viewer",
+		"SCOPES":              "a,b",
+		"PORT":                "8080",
+		"TARGET":              "https://example.com",
+		"SUBJECT_DAILY_QUOTA": "5",
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	cfg, err := configFromEnv()
+	if err != nil {
+		t.Fatalf("configFromEnv() = %v", err)
+	}
+	if !cfg.Evaluator.Validator.GetConstant().GetValue() {
+		t.Errorf("Evaluator = %v, want constant true validator", cfg.Evaluator)
+	}
+	if got := cfg.Warehouse.Scopes; len(got) != 2 {
+		t.Errorf("Scopes = %v, want [a b]", got)
+	}
+	if got := cfg.Listener.Address; got != ":8080" {
+		t.Errorf("Address = %q, want \":8080\"", got)
+	}
+	if got := cfg.RateLimits.SubjectDailyQuota; got != 5 {
+		t.Errorf("SubjectDailyQuota = %d, want 5", got)
+	}
+}
+
+func TestConfigFromEnvNoEvaluator(t *testing.T) {
+	os.Unsetenv("EVALUATOR")
+	os.Unsetenv("EVALUATOR_FILE")
+	if _, err := configFromEnv(); err == nil {
+		t.Fatal("configFromEnv() succeeded without EVALUATOR or EVALUATOR_FILE, want error")
+	}
+}
@@ -13,26 +13,32 @@
 // limitations under the License.
 
 // The key-vendor daemon returns Google Cloud Platform service account keys for
-// external GA4GH identities.
+// external GA4GH identities.  It is configured either with a text proto
+// server.Config passed via the -config flag or, on App Engine, with the
+// environment variables described in app.yaml.
 package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
-	"os"
 
 	ga4gh "github.com/googlegenomics/ga4gh-identity"
-	"github.com/googlegenomics/ga4gh-identity/gcp/internal/appengine"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+	"github.com/googlegenomics/ga4gh-identity/gcp/ratelimit"
 )
 
 func main() {
+	flag.Parse()
+	cfg := server.MustLoadConfig()
+
 	ctx := context.Background()
-	ev := appengine.MustBuildEvaluator(ctx)
-	wh := appengine.MustBuildAccountWarehouse(ctx)
+	ev := server.MustBuildEvaluator(ctx, cfg)
+	wh := server.MustBuildAccountWarehouse(ctx, cfg)
+	limiter := server.MustBuildLimiter(cfg)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/v1/GetAccountKey", func(w http.ResponseWriter, req *http.Request) {
+	getAccountKey := func(w http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
 		id, ok := ga4gh.IdentityFromContext(ctx)
 		if !ok {
@@ -51,10 +57,18 @@ func main() {
 			log.Printf("Error writing response: %v", err)
 			return
 		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/GetAccountKey", &ratelimit.Handler{
+		Limiter: limiter,
+		Handler: http.HandlerFunc(getAccountKey),
 	})
 	handler := ga4gh.Handler{
 		Evaluator: ev,
 		Handler:   mux,
 	}
-	log.Fatal(http.ListenAndServe(":"+os.Getenv("PORT"), &handler))
+	if err := server.ListenAndServe(cfg, &handler); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,252 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const defaultAssertionLifetime = 5 * time.Minute
+
+// identityHeaders sets headers describing a verified identity on forwarded
+// requests.
+type identityHeaders struct {
+	issuer  string
+	subject string
+	// claims maps ga4gh.Identity field names to header names.  Each header
+	// holds the query escaped values of its claim, separated by commas, so that
+	// values containing commas remain distinct.
+	claims    map[string]string
+	assertion *assertion
+}
+
+type assertion struct {
+	header   string
+	signer   jose.Signer
+	issuer   string
+	audience string
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+func buildIdentityHeaders(h *server.IdentityHeaders) (*identityHeaders, error) {
+	if h == nil {
+		return nil, nil
+	}
+	built := &identityHeaders{
+		issuer:  h.Issuer,
+		subject: h.Subject,
+		claims:  h.Claims,
+	}
+	identity := reflect.TypeOf(ga4gh.Identity{})
+	for name := range h.Claims {
+		if _, ok := identity.FieldByName(name); !ok {
+			return nil, fmt.Errorf("no field named %q on ga4gh.Identity", name)
+		}
+	}
+	if a := h.Assertion; a != nil {
+		var err error
+		if built.assertion, err = buildAssertion(a); err != nil {
+			return nil, fmt.Errorf("building assertion: %v", err)
+		}
+	}
+	return built, nil
+}
+
+func buildAssertion(a *server.Assertion) (*assertion, error) {
+	if a.Header == "" {
+		return nil, errors.New("assertion header must be set")
+	}
+	lifetime := defaultAssertionLifetime
+	if a.Lifetime != "" {
+		var err error
+		if lifetime, err = time.ParseDuration(a.Lifetime); err != nil {
+			return nil, fmt.Errorf("parsing lifetime: %v", err)
+		}
+	}
+	b, err := ioutil.ReadFile(a.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %v", err)
+	}
+	signer, err := newSigner(b)
+	if err != nil {
+		return nil, fmt.Errorf("creating signer: %v", err)
+	}
+	return &assertion{
+		header:   a.Header,
+		signer:   signer,
+		issuer:   a.Issuer,
+		audience: a.Audience,
+		lifetime: lifetime,
+		now:      time.Now,
+	}, nil
+}
+
+// newSigner creates a JWT signer from a PEM encoded RSA or ECDSA private key.
+func newSigner(key []byte) (jose.Signer, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", block.Type, err)
+	}
+
+	var alg jose.SignatureAlgorithm
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		switch k.Curve.Params().BitSize {
+		case 256:
+			alg = jose.ES256
+		case 384:
+			alg = jose.ES384
+		case 521:
+			alg = jose.ES512
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported %T key", parsed)
+	}
+	return jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: parsed}, (&jose.SignerOptions{}).WithType("JWT"))
+}
+
+// strip removes all of the identity headers from req.
+func (h *identityHeaders) strip(req *http.Request) {
+	if h == nil {
+		return
+	}
+	for _, name := range h.names() {
+		req.Header.Del(name)
+	}
+}
+
+func (h *identityHeaders) names() []string {
+	var names []string
+	for _, name := range []string{h.issuer, h.subject} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	for _, name := range h.claims {
+		names = append(names, name)
+	}
+	if h.assertion != nil {
+		names = append(names, h.assertion.header)
+	}
+	return names
+}
+
+// inject sets the identity headers on req to describe id.
+func (h *identityHeaders) inject(req *http.Request, id *ga4gh.Identity) error {
+	if h == nil {
+		return nil
+	}
+	if h.issuer != "" {
+		req.Header.Set(h.issuer, id.Issuer)
+	}
+	if h.subject != "" {
+		req.Header.Set(h.subject, id.Subject)
+	}
+	v := reflect.ValueOf(*id)
+	for name, header := range h.claims {
+		values := claimValues(v.FieldByName(name))
+		if len(values) == 0 {
+			continue
+		}
+		for i, value := range values {
+			values[i] = url.QueryEscape(value)
+		}
+		req.Header.Set(header, strings.Join(values, ","))
+	}
+	if h.assertion != nil {
+		token, err := h.assertion.sign(id)
+		if err != nil {
+			return fmt.Errorf("signing assertion: %v", err)
+		}
+		req.Header.Set(h.assertion.header, token)
+	}
+	return nil
+}
+
+// claimValues formats the values of an Identity field as strings.
+func claimValues(field reflect.Value) []string {
+	switch field.Kind() {
+	case reflect.String:
+		if s := field.String(); s != "" {
+			return []string{s}
+		}
+	case reflect.Bool:
+		return []string{strconv.FormatBool(field.Bool())}
+	case reflect.Slice:
+		var values []string
+		for i := 0; i < field.Len(); i++ {
+			elem := field.Index(i)
+			if elem.Kind() == reflect.Struct {
+				elem = elem.FieldByName("Value")
+			}
+			values = append(values, claimValues(elem)...)
+		}
+		return values
+	}
+	return nil
+}
+
+func (a *assertion) sign(id *ga4gh.Identity) (string, error) {
+	now := a.now()
+	claims := jwt.Claims{
+		Issuer:   a.issuer,
+		Subject:  id.Subject,
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(a.lifetime)),
+	}
+	if a.audience != "" {
+		claims.Audience = jwt.Audience{a.audience}
+	}
+	identity := struct {
+		Identity *ga4gh.Identity `json:"identity"`
+	}{id}
+	return jwt.Signed(a.signer).Claims(claims).Claims(identity).CompactSerialize()
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestIdentityHeaders(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshaling key: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "headers")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Error writing key: %v", err)
+	}
+
+	h, err := buildIdentityHeaders(&server.IdentityHeaders{
+		Issuer:  "X-Identity-Issuer",
+		Subject: "X-Identity-Subject",
+		Claims:  map[string]string{"Role": "X-Identity-Role"},
+		Assertion: &server.Assertion{
+			Header:   "X-Identity-Assertion",
+			KeyFile:  keyFile,
+			Issuer:   "https://proxy.example.com",
+			Audience: "upstream",
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildIdentityHeaders() = %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Identity-Subject", "spoofed")
+	req.Header.Set("X-Identity-Role", "admin")
+	h.strip(req)
+	if got := req.Header.Get("X-Identity-Subject"); got != "" {
+		t.Fatalf("X-Identity-Subject = %q after strip, want empty", got)
+	}
+
+	id := &ga4gh.Identity{
+		Issuer:  "https://idp.example.com",
+		Subject: "alice",
+		Role:    []ga4gh.StringValue{{Value: "researcher"}, {Value: "PI, Lab A"}},
+	}
+	if err := h.inject(req, id); err != nil {
+		t.Fatalf("inject() = %v", err)
+	}
+	for header, want := range map[string]string{
+		"X-Identity-Issuer":  "https://idp.example.com",
+		"X-Identity-Subject": "alice",
+		"X-Identity-Role":    "researcher,PI%2C+Lab+A",
+	} {
+		if got := req.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want = %q", header, got, want)
+		}
+	}
+
+	token, err := jwt.ParseSigned(req.Header.Get("X-Identity-Assertion"))
+	if err != nil {
+		t.Fatalf("Error parsing assertion: %v", err)
+	}
+	var claims jwt.Claims
+	var extra struct {
+		Identity ga4gh.Identity `json:"identity"`
+	}
+	if err := token.Claims(&key.PublicKey, &claims, &extra); err != nil {
+		t.Fatalf("Error verifying assertion: %v", err)
+	}
+	if err := claims.Validate(jwt.Expected{Issuer: "https://proxy.example.com", Subject: "alice", Audience: jwt.Audience{"upstream"}}); err != nil {
+		t.Errorf("Invalid assertion claims: %v", err)
+	}
+	if extra.Identity.Issuer != id.Issuer || len(extra.Identity.Role) != 2 {
+		t.Errorf("Assertion identity = %+v, want = %+v", extra.Identity, id)
+	}
+}
+
+func TestIdentityHeadersUnknownClaim(t *testing.T) {
+	if _, err := buildIdentityHeaders(&server.IdentityHeaders{Claims: map[string]string{"Rol": "X-Role"}}); err == nil {
+		t.Fatal("buildIdentityHeaders() succeeded with an unknown claim")
+	}
+}
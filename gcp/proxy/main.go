@@ -12,50 +12,58 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// This package provides a single-host reverse proxy that rewrites bearer
-// tokens in Authorization headers to be Google Cloud Platform access tokens.
-// For configuration information see app.yaml.
+// This package provides a reverse proxy that rewrites bearer tokens in
+// Authorization headers to be Google Cloud Platform access tokens.  Requests
+// are forwarded to a single target, or routed between several targets by host
+// and path prefix.
+// It is configured either with a text proto server.Config passed via the
+// -config flag or, on App Engine, with the environment variables described in
+// app.yaml.
 package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
 	"net/http"
 	"net/http/httputil"
-	"net/url"
-	"os"
 	"strings"
 
 	ga4gh "github.com/googlegenomics/ga4gh-identity"
 	"github.com/googlegenomics/ga4gh-identity/gcp"
-	"github.com/googlegenomics/ga4gh-identity/gcp/internal/appengine"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+	"github.com/googlegenomics/ga4gh-identity/gcp/ratelimit"
 )
 
 func main() {
-	target := os.Getenv("TARGET")
-	t, err := url.Parse(target)
-	if err != nil {
-		log.Fatalf("Error parsing TARGET=%q: %v", target, err)
-	}
+	flag.Parse()
+	cfg := server.MustLoadConfig()
 
 	ctx := context.Background()
-	ev := appengine.MustBuildEvaluator(ctx)
-	wh := appengine.MustBuildAccountWarehouse(ctx)
-	log.Fatal(http.ListenAndServe(":"+os.Getenv("PORT"), newProxy(t, ev, wh)))
+	routes, err := buildRoutes(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Error building routes: %v", err)
+	}
+	wh := server.MustBuildAccountWarehouse(ctx, cfg)
+	limiter := server.MustBuildLimiter(cfg)
+	if err := server.ListenAndServe(cfg, newProxy(routes, wh, limiter)); err != nil {
+		log.Fatal(err)
+	}
 }
 
 type proxy struct {
 	*httputil.ReverseProxy
-	target    *url.URL
-	evaluator *ga4gh.Evaluator
+	routes    []*route
 	warehouse *gcp.AccountWarehouse
+	limiter   *ratelimit.Limiter
 }
 
-func newProxy(target *url.URL, evaluator *ga4gh.Evaluator, warehouse *gcp.AccountWarehouse) *proxy {
+func newProxy(routes []*route, warehouse *gcp.AccountWarehouse, limiter *ratelimit.Limiter) *proxy {
 	p := &proxy{
-		target:    target,
-		evaluator: evaluator,
+		routes:    routes,
 		warehouse: warehouse,
+		limiter:   limiter,
 	}
 	p.ReverseProxy = &httputil.ReverseProxy{
 		Director: p.director,
@@ -63,30 +71,112 @@ func newProxy(target *url.URL, evaluator *ga4gh.Evaluator, warehouse *gcp.Accoun
 	return p
 }
 
-func (p *proxy) director(req *http.Request) {
-	auth := strings.Fields(req.Header.Get("Authorization"))
-	if len(auth) == 2 && strings.ToLower(auth[0]) == "bearer" {
-		p.swapAuthHeader(req, auth[1])
+// ServeHTTP selects the route for req and swaps the incoming bearer token for
+// a Google Cloud Platform access token, and any configured identity headers,
+// before forwarding the request.
+// Requests with ambiguous paths, matching no route, denied by a route policy,
+// or whose identity has exceeded its issuance limits are rejected rather than
+// forwarded.  Requests
+// for a route with policies are also rejected unless they present a bearer
+// token that evaluates to an identity.
+func (p *proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := cleanPath(req.URL); err != nil {
+		log.Printf("Rejecting request: %v", err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	r := matchRoute(p.routes, req)
+	if r == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
 	}
 
+	r.headers.strip(req)
+	if err := p.swapAuthHeader(req, r); err != nil {
+		switch err {
+		case errUnauthenticated:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		case errForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		default:
+			ratelimit.WriteError(w, err)
+		}
+		return
+	}
+	p.ReverseProxy.ServeHTTP(w, req.WithContext(newRouteContext(req.Context(), r)))
+}
+
+func (p *proxy) director(req *http.Request) {
+	r := routeFromContext(req.Context())
 	req.Host = ""
-	req.URL.Scheme = p.target.Scheme
-	req.URL.Host = p.target.Host
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	r.rewritePath(req.URL)
 }
 
-func (p *proxy) swapAuthHeader(req *http.Request, auth string) {
+var (
+	// errUnauthenticated is returned by swapAuthHeader when a route with
+	// policies is requested without an identity.
+	errUnauthenticated = errors.New("no identity for route with policies")
+	// errForbidden is returned by swapAuthHeader when a route policy denies
+	// the request.
+	errForbidden = errors.New("forbidden by route policy")
+)
+
+// swapAuthHeader replaces the authorization header in req with an access token
+// for the identity of its bearer token, as evaluated by r.  If r has policies
+// then a missing token or a failure to evaluate it is returned as
+// errUnauthenticated; otherwise the request is forwarded without an identity.
+// Failures to generate a token are logged and leave the header untouched; only
+// a missing identity, a policy denial or an exceeded limit is returned as an
+// error.
+func (p *proxy) swapAuthHeader(req *http.Request, r *route) error {
+	auth := strings.Fields(req.Header.Get("Authorization"))
+	if len(auth) != 2 || strings.ToLower(auth[0]) != "bearer" {
+		if len(r.policies) > 0 {
+			return errUnauthenticated
+		}
+		return nil
+	}
+
 	ctx := req.Context()
-	id, err := p.evaluator.Evaluate(ctx, auth)
+	id, err := r.evaluator.Evaluate(ctx, auth[1])
 	if err != nil {
 		log.Printf("Error during evaluation: %v", err)
-		return
+		if len(r.policies) > 0 {
+			return errUnauthenticated
+		}
+		return nil
 	}
 
-	token, err := p.warehouse.GetAccessToken(ctx, id.Subject)
+	authz, _ := r.evaluator.(ga4gh.Authorizer)
+	ok, err := authorize(ctx, r.policies, authz, req, id)
+	if err != nil {
+		log.Printf("Error evaluating policy for %s %s: %v", req.Method, req.URL.Path, err)
+		return errForbidden
+	}
+	if !ok {
+		return errForbidden
+	}
+
+	if err := p.limiter.Allow(ctx, id.Issuer, id.Subject); err != nil {
+		log.Printf("Rate limiting %q: %v", id.Subject, err)
+		return err
+	}
+
+	token, err := p.warehouse.GetScopedAccessToken(ctx, id.Subject, r.scopes)
 	if err != nil {
 		log.Printf("Error getting access token: %v", err)
-		return
+		return nil
+	}
+
+	if err := r.headers.inject(req, id); err != nil {
+		log.Printf("Error adding identity headers: %v", err)
+		r.headers.strip(req)
+		return nil
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
 }
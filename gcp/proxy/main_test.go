@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+)
+
+// tokenEvaluator evaluates a fixed set of tokens.
+type tokenEvaluator map[string]*ga4gh.Identity
+
+func (e tokenEvaluator) Evaluate(ctx context.Context, auth string) (*ga4gh.Identity, error) {
+	id, ok := e[auth]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return id, nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+	target, err := url.Parse(backend.URL)
+	if err != nil {
+		t.Fatalf("Error parsing backend URL: %v", err)
+	}
+
+	var cfg server.Route
+	if err := proto.UnmarshalText(`
+		policies {
+			validator { simple { claims { key: "Role" value: "cohort-a" } } }
+		}
+	`, &cfg); err != nil {
+		t.Fatalf("Error parsing route: %v", err)
+	}
+	policies, err := buildPolicies(context.Background(), cfg.Policies)
+	if err != nil {
+		t.Fatalf("buildPolicies() = %v", err)
+	}
+	ev := tokenEvaluator{"outsider": &ga4gh.Identity{Subject: "outsider"}}
+	p := newProxy([]*route{
+		{pathPrefix: "/protected/", target: target, evaluator: ev, policies: policies},
+		{pathPrefix: "/public/", target: target, evaluator: ev},
+	}, nil, nil)
+
+	tests := []struct {
+		name string
+		path string
+		auth string
+		want int
+	}{
+		{name: "no token", path: "/protected/x", want: http.StatusUnauthorized},
+		{name: "not a bearer token", path: "/protected/x", auth: "Basic b3V0c2lkZXI=", want: http.StatusUnauthorized},
+		{name: "bad token", path: "/protected/x", auth: "Bearer forged", want: http.StatusUnauthorized},
+		{name: "denied", path: "/protected/x", auth: "Bearer outsider", want: http.StatusForbidden},
+		{name: "no policies", path: "/public/x", want: http.StatusOK},
+		{name: "no policies with bad token", path: "/public/x", auth: "Bearer forged", want: http.StatusOK},
+		{name: "no route", path: "/other", want: http.StatusNotFound},
+		{name: "dot segments", path: "/public/../protected/x", want: http.StatusBadRequest},
+		{name: "empty segment", path: "/public//x", want: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			if test.auth != "" {
+				req.Header.Set("Authorization", test.auth)
+			}
+			w := httptest.NewRecorder()
+			p.ServeHTTP(w, req)
+			if w.Code != test.want {
+				t.Fatalf("ServeHTTP() status = %d, want = %d", w.Code, test.want)
+			}
+		})
+	}
+}
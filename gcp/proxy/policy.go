@@ -0,0 +1,167 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/builder"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+)
+
+// policy is an authorization rule for requests with particular methods and
+// paths.  Identities must pass the validator, if any, and be authorized for
+// the dataset, if any.
+type policy struct {
+	methods   map[string]bool
+	path      *regexp.Regexp
+	validator ga4gh.Validator
+	dataset   string
+}
+
+func buildPolicies(ctx context.Context, ps []*server.Policy) ([]*policy, error) {
+	var built []*policy
+	for i, p := range ps {
+		path, err := compilePathPattern(p.PathPattern)
+		if err != nil {
+			return nil, fmt.Errorf("policy %d: compiling path pattern %q: %v", i, p.PathPattern, err)
+		}
+		var v ga4gh.Validator
+		if p.Validator != nil || p.Dataset == "" {
+			if v, err = builder.BuildValidator(ctx, p.Validator); err != nil {
+				return nil, fmt.Errorf("policy %d: building validator: %v", i, err)
+			}
+		}
+		var methods map[string]bool
+		if len(p.Methods) > 0 {
+			methods = make(map[string]bool)
+			for _, m := range p.Methods {
+				methods[strings.ToUpper(m)] = true
+			}
+		}
+		built = append(built, &policy{
+			methods:   methods,
+			path:      path,
+			validator: v,
+			dataset:   p.Dataset,
+		})
+	}
+	return built, nil
+}
+
+// compilePathPattern converts a path pattern, in which "*" matches anything
+// other than "/" and "**" matches anything, into an anchored regular
+// expression.
+func compilePathPattern(pattern string) (*regexp.Regexp, error) {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			re.WriteString("[^/]*")
+			i++
+		default:
+			j := strings.IndexByte(pattern[i:], '*')
+			if j < 0 {
+				j = len(pattern) - i
+			}
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+j]))
+			i += j
+		}
+	}
+	if pattern == "" {
+		re.WriteString(".*")
+	}
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
+
+// matches returns true iff p applies to req, whose path must have been
+// cleaned with cleanPath.
+func (p *policy) matches(req *http.Request) bool {
+	if p.methods != nil && !p.methods[req.Method] {
+		return false
+	}
+	return p.path.MatchString(req.URL.EscapedPath())
+}
+
+// cleanPath converts the path of u into a canonical escaped form, in which
+// each segment is unescaped and then escaped again by url.PathEscape, so that
+// routes, policies and targets all see the same path.  It returns an error if
+// the path contains empty, "." or ".." segments, including escaped ones and
+// those separated by escaped slashes, which targets may resolve to other
+// resources than routes and policies would.  A trailing "/" is allowed.
+func cleanPath(u *url.URL) error {
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, s := range segments {
+		segment, err := url.PathUnescape(s)
+		if err != nil {
+			return err
+		}
+		trailing := i > 0 && i == len(segments)-1
+		if segment == "" && i > 0 && !trailing {
+			return fmt.Errorf("path %q contains an empty segment", u.EscapedPath())
+		}
+		// Targets that decode escaped slashes would see these parts as
+		// segments too.
+		for _, part := range strings.Split(segment, "/") {
+			if part == "." || part == ".." {
+				return fmt.Errorf("path %q contains %q segment", u.EscapedPath(), s)
+			}
+		}
+		segments[i] = url.PathEscape(segment)
+	}
+	escaped := strings.Join(segments, "/")
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return err
+	}
+	u.Path, u.RawPath = path, escaped
+	return nil
+}
+
+// authorize returns true iff id is permitted to make req according to the
+// first of policies that matches req.  Dataset policies are decided by authz,
+// which may be nil if the route has no datasets.  If no policy matches then
+// the request is permitted.
+func authorize(ctx context.Context, policies []*policy, authz ga4gh.Authorizer, req *http.Request, id *ga4gh.Identity) (bool, error) {
+	for _, p := range policies {
+		if !p.matches(req) {
+			continue
+		}
+		if p.validator != nil {
+			if ok, err := p.validator.Validate(ctx, id); !ok || err != nil {
+				return false, err
+			}
+		}
+		if p.dataset == "" {
+			return true, nil
+		}
+		if authz == nil {
+			return false, fmt.Errorf("dataset %q: route evaluator does not authorize datasets", p.dataset)
+		}
+		return authz.Authorize(ctx, id, p.dataset)
+	}
+	return true, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+	"github.com/googlegenomics/ga4gh-identity/validator"
+)
+
+func TestCompilePathPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "", path: "/anything/at/all", want: true},
+		{pattern: "/b/*/o", path: "/b/cohort-a/o", want: true},
+		{pattern: "/b/*/o", path: "/b/cohort-a/x/o", want: false},
+		{pattern: "/b/cohort-a/**", path: "/b/cohort-a/o/dir/object", want: true},
+		{pattern: "/b/cohort-a/**", path: "/b/cohort-ab/o", want: false},
+		{pattern: "/v1.0/*", path: "/v1x0/x", want: false},
+	}
+	for _, test := range tests {
+		re, err := compilePathPattern(test.pattern)
+		if err != nil {
+			t.Fatalf("compilePathPattern(%q) = %v", test.pattern, err)
+		}
+		if got := re.MatchString(test.path); got != test.want {
+			t.Errorf("pattern %q matching %q = %v, want = %v", test.pattern, test.path, got, test.want)
+		}
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/", want: "/"},
+		{path: "/storage/v1/b/cohort-a/o/x", want: "/storage/v1/b/cohort-a/o/x"},
+		{path: "/storage/v1/b/cohort-a/o/", want: "/storage/v1/b/cohort-a/o/"},
+		{path: "/storage/v1/b/cohort-%61/o/x", want: "/storage/v1/b/cohort-a/o/x"},
+		{path: "/storage/v1/b/cohort-a/o/dir%2fx", want: "/storage/v1/b/cohort-a/o/dir%2Fx"},
+		{path: "/storage/v1/b/x%2F..%2Fcohort-a/o/x"},
+		{path: "/storage/v1/b/x%2f%2e%2e%2fcohort-a/o/x"},
+		{path: "/storage/v1/b/x/../cohort-a/o/x"},
+		{path: "/storage/v1/b/./cohort-a/o/x"},
+		{path: "/storage/v1/b//cohort-a/o/x"},
+		{path: "/storage/v1/b/x/%2e%2e/cohort-a/o/x"},
+		{path: "/storage/v1/b/x/%2E/cohort-a/o/x"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			err := cleanPath(req.URL)
+			if test.want == "" {
+				if err == nil {
+					t.Fatalf("cleanPath() succeeded with %q, want error", req.URL.EscapedPath())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cleanPath() = %v", err)
+			}
+			if got := req.URL.EscapedPath(); got != test.want {
+				t.Fatalf("cleanPath() = %q, want = %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	var cfg server.Route
+	if err := proto.UnmarshalText(`
+		policies {
+			methods: "get"
+			path_pattern: "/storage/v1/b/cohort-a/**"
+			validator { simple { claims { key: "Role" value: "cohort-a" } } }
+		}
+		policies {
+			path_pattern: "/storage/v1/b/cohort-a/**"
+		}
+	`, &cfg); err != nil {
+		t.Fatalf("Error parsing route: %v", err)
+	}
+	ctx := context.Background()
+	policies, err := buildPolicies(ctx, cfg.Policies)
+	if err != nil {
+		t.Fatalf("buildPolicies() = %v", err)
+	}
+
+	member := &ga4gh.Identity{Role: []ga4gh.StringValue{{Value: "cohort-a"}}}
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		id     *ga4gh.Identity
+		want   bool
+	}{
+		{name: "member read", method: "GET", path: "/storage/v1/b/cohort-a/o/x", id: member, want: true},
+		{name: "non-member read", method: "GET", path: "/storage/v1/b/cohort-a/o/x", id: &ga4gh.Identity{}, want: false},
+		{name: "member write", method: "POST", path: "/storage/v1/b/cohort-a/o", id: member, want: false},
+		{name: "public read", method: "GET", path: "/storage/v1/b/reference/o/x", id: &ga4gh.Identity{}, want: true},
+		{name: "escaped bucket", method: "GET", path: "/storage/v1/b/cohort-%61/o/x", id: &ga4gh.Identity{}, want: false},
+		{name: "escaped slash in object", method: "GET", path: "/storage/v1/b/cohort-a/o/dir%2fx", id: &ga4gh.Identity{}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if err := cleanPath(req.URL); err != nil {
+				t.Fatalf("cleanPath() = %v", err)
+			}
+			got, err := authorize(ctx, policies, nil, req, test.id)
+			if err != nil {
+				t.Fatalf("authorize() = %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("authorize() = %v, want = %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeDatasets(t *testing.T) {
+	var cfg server.Route
+	if err := proto.UnmarshalText(`
+		policies {
+			path_pattern: "/storage/v1/b/cohort-a/**"
+			dataset: "phs000710"
+		}
+		policies {
+			path_pattern: "/storage/v1/b/cohort-b/**"
+			validator { simple { claims { key: "Role" value: "curator" } } }
+			dataset: "phs000711"
+		}
+	`, &cfg); err != nil {
+		t.Fatalf("Error parsing route: %v", err)
+	}
+	ctx := context.Background()
+	policies, err := buildPolicies(ctx, cfg.Policies)
+	if err != nil {
+		t.Fatalf("buildPolicies() = %v", err)
+	}
+	datasets := validator.Datasets{
+		"phs000710": validator.Simple{"Role": "researcher"},
+		"phs000711": &validator.Constant{OK: true},
+	}
+
+	researcher := &ga4gh.Identity{Role: []ga4gh.StringValue{{Value: "researcher"}}}
+	curator := &ga4gh.Identity{Role: []ga4gh.StringValue{{Value: "curator"}}}
+	tests := []struct {
+		name  string
+		path  string
+		id    *ga4gh.Identity
+		authz ga4gh.Authorizer
+		want  bool
+		err   bool
+	}{
+		{name: "authorized", path: "/storage/v1/b/cohort-a/o/x", id: researcher, authz: datasets, want: true},
+		{name: "not authorized", path: "/storage/v1/b/cohort-a/o/x", id: curator, authz: datasets, want: false},
+		{name: "validator and dataset", path: "/storage/v1/b/cohort-b/o/x", id: curator, authz: datasets, want: true},
+		{name: "validator fails", path: "/storage/v1/b/cohort-b/o/x", id: researcher, authz: datasets, want: false},
+		{name: "no authorizer", path: "/storage/v1/b/cohort-a/o/x", id: researcher, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			got, err := authorize(ctx, policies, test.authz, req, test.id)
+			if test.err != (err != nil) {
+				t.Fatalf("authorize() = %v, want error = %v", err, test.err)
+			}
+			if got != test.want {
+				t.Fatalf("authorize() = %v, want = %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/builder"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+)
+
+// route describes where requests for a host and path prefix are forwarded,
+// and how identities presented with them are evaluated.
+type route struct {
+	host       string
+	pathPrefix string
+	rewrite    string
+	target     *url.URL
+	scopes     []string
+	evaluator  ga4gh.IdentityEvaluator
+	policies   []*policy
+	headers    *identityHeaders
+}
+
+// buildRoutes constructs the routes described by cfg.  If cfg has no routes
+// then a single route forwarding everything to cfg.Target is returned.
+func buildRoutes(ctx context.Context, cfg *server.Config) ([]*route, error) {
+	defaultEvaluator, err := server.BuildEvaluator(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building evaluator: %v", err)
+	}
+
+	routes := cfg.Routes
+	if len(routes) == 0 {
+		routes = []*server.Route{{Target: cfg.Target}}
+	}
+
+	var built []*route
+	for i, r := range routes {
+		target, err := url.Parse(r.Target)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: parsing target %q: %v", i, r.Target, err)
+		}
+		if target.Host == "" {
+			return nil, fmt.Errorf("route %d: target %q must be an absolute URL", i, r.Target)
+		}
+
+		for _, p := range []string{r.PathPrefix, r.RewritePrefix} {
+			if _, err := url.PathUnescape(p); err != nil {
+				return nil, fmt.Errorf("route %d: invalid path %q: %v", i, p, err)
+			}
+		}
+
+		ev := defaultEvaluator
+		if r.Evaluator != nil {
+			if err := builder.CheckLint(r.Evaluator); err != nil {
+				return nil, fmt.Errorf("route %d: %v", i, err)
+			}
+			if ev, err = builder.Build(ctx, r.Evaluator); err != nil {
+				return nil, fmt.Errorf("route %d: building evaluator: %v", i, err)
+			}
+		}
+		if ev == nil {
+			return nil, fmt.Errorf("route %d: no evaluator configured", i)
+		}
+
+		policies, err := buildPolicies(ctx, r.Policies)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: %v", i, err)
+		}
+
+		headerConfig := cfg.IdentityHeaders
+		if r.IdentityHeaders != nil {
+			headerConfig = r.IdentityHeaders
+		}
+		headers, err := buildIdentityHeaders(headerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: building identity headers: %v", i, err)
+		}
+
+		scopes := r.Scopes
+		if len(scopes) == 0 {
+			scopes = cfg.GetWarehouse().GetScopes()
+		}
+
+		built = append(built, &route{
+			host:       strings.ToLower(r.Host),
+			pathPrefix: r.PathPrefix,
+			rewrite:    r.RewritePrefix,
+			target:     target,
+			scopes:     scopes,
+			evaluator:  ev,
+			policies:   policies,
+			headers:    headers,
+		})
+	}
+	return built, nil
+}
+
+// matches returns true iff req should be handled by r.
+func (r *route) matches(req *http.Request) bool {
+	if r.host != "" {
+		host := req.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if strings.ToLower(host) != r.host {
+			return false
+		}
+	}
+	return hasPathPrefix(req.URL.EscapedPath(), r.pathPrefix)
+}
+
+// hasPathPrefix returns true iff prefix is empty, or matches whole segments at
+// the start of path, so that "/storage" matches "/storage/b" but not
+// "/storagefoo".
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if prefix == "" || strings.HasSuffix(prefix, "/") || len(path) == len(prefix) {
+		return true
+	}
+	return path[len(prefix)] == '/'
+}
+
+// rewritePath applies the route's path rewrite, if any, to u.  The rewrite is
+// applied to the escaped path, so that escaped characters such as "%2F" in the
+// rest of the path are preserved.
+func (r *route) rewritePath(u *url.URL) {
+	if r.rewrite == "" {
+		return
+	}
+	escaped := r.rewrite + strings.TrimPrefix(u.EscapedPath(), r.pathPrefix)
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		log.Printf("Error rewriting path %q: %v", escaped, err)
+		return
+	}
+	u.Path = path
+	u.RawPath = escaped
+}
+
+// matchRoute returns the first route in routes that matches req, or nil if
+// there is none.
+func matchRoute(routes []*route, req *http.Request) *route {
+	for _, r := range routes {
+		if r.matches(req) {
+			return r
+		}
+	}
+	return nil
+}
+
+type routeKey struct{}
+
+func newRouteContext(ctx context.Context, r *route) context.Context {
+	return context.WithValue(ctx, routeKey{}, r)
+}
+
+func routeFromContext(ctx context.Context) *route {
+	r, _ := ctx.Value(routeKey{}).(*route)
+	return r
+}
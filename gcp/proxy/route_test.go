@@ -0,0 +1,112 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googlegenomics/ga4gh-identity/builder"
+	"github.com/googlegenomics/ga4gh-identity/gcp/internal/server"
+)
+
+func TestMatchRoute(t *testing.T) {
+	storage := &route{pathPrefix: "/storage/", rewrite: "/"}
+	bigquery := &route{host: "bq.example.com"}
+	bucket := &route{pathPrefix: "/bucket", rewrite: "/storage/v1/b/bucket"}
+	fallback := &route{}
+	routes := []*route{storage, bigquery, bucket, fallback}
+
+	tests := []struct {
+		name string
+		url  string
+		want *route
+		path string
+	}{
+		{
+			name: "path prefix with rewrite",
+			url:  "https://proxy.example.com/storage/b/bucket/o/object",
+			want: storage,
+			path: "/b/bucket/o/object",
+		},
+		{
+			name: "host with port",
+			url:  "https://BQ.example.com:443/bigquery/v2/projects",
+			want: bigquery,
+			path: "/bigquery/v2/projects",
+		},
+		{
+			name: "fallback",
+			url:  "https://proxy.example.com/v1/projects",
+			want: fallback,
+			path: "/v1/projects",
+		},
+		{
+			name: "escaped path",
+			url:  "https://proxy.example.com/bucket/o/dir%2Fobject",
+			want: bucket,
+			path: "/storage/v1/b/bucket/o/dir%2Fobject",
+		},
+		{
+			name: "whole prefix",
+			url:  "https://proxy.example.com/bucket",
+			want: bucket,
+			path: "/storage/v1/b/bucket",
+		},
+		{
+			name: "partial segment",
+			url:  "https://proxy.example.com/bucketfoo/o",
+			want: fallback,
+			path: "/bucketfoo/o",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.url, nil)
+			got := matchRoute(routes, req)
+			if got != test.want {
+				t.Fatalf("matchRoute() = %+v, want = %+v", got, test.want)
+			}
+			got.rewritePath(req.URL)
+			if got := req.URL.EscapedPath(); got != test.path {
+				t.Fatalf("rewritten path = %q, want = %q", got, test.path)
+			}
+		})
+	}
+
+	if got := matchRoute(routes[:2], httptest.NewRequest("GET", "/other", nil)); got != nil {
+		t.Fatalf("matchRoute() = %+v, want = nil", got)
+	}
+}
+
+func TestBuildRoutesLint(t *testing.T) {
+	// The route evaluator has no parser, which Lint reports as an error.
+	cfg := &server.Config{
+		Routes: []*server.Route{{
+			Target: "https://example.com",
+			Evaluator: &builder.Evaluator{
+				Validator: &builder.Validator{Validator: &builder.Validator_Constant_{
+					Constant: &builder.Validator_Constant{Value: true},
+				}},
+			},
+		}},
+	}
+	_, err := buildRoutes(context.Background(), cfg)
+	if err == nil || !strings.Contains(err.Error(), "missing parser") {
+		t.Fatalf("buildRoutes() = %v, want missing parser lint error", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type counter struct {
+	count int64
+	reset time.Time
+}
+
+// MemoryStore is a Store that keeps counters in memory.  Counters are not
+// shared between processes.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	nextSweep time.Time
+}
+
+// NewMemoryStore creates a new, empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*counter)}
+}
+
+// Increment implements the Store interface.
+func (s *MemoryStore) Increment(_ context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired counters are removed periodically so that memory use is bounded
+	// by the number of identities seen in the longest window.
+	if !now.Before(s.nextSweep) {
+		for k, c := range s.counters {
+			if !now.Before(c.reset) {
+				delete(s.counters, k)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.reset) {
+		c = &counter{reset: now.Truncate(window).Add(window)}
+		s.counters[key] = c
+	}
+	c.count++
+	return c.count, c.reset, nil
+}
+
+// Decrement implements the Store interface.
+func (s *MemoryStore) Decrement(_ context.Context, key string, window time.Duration, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.counters[key]; ok && now.Before(c.reset) && c.count > 0 {
+		c.count--
+	}
+	return nil
+}
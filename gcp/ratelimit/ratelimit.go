@@ -0,0 +1,227 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides per-identity and global limits on how often
+// credentials may be issued.  Limits are enforced using fixed-window counters
+// held in a Store, which may be shared between multiple instances.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Day is the window used for daily issuance quotas.
+const Day = 24 * time.Hour
+
+// Rate is a maximum number of events allowed in a fixed window of time.  The
+// zero Rate imposes no limit.
+type Rate struct {
+	Count  int64
+	Window time.Duration
+}
+
+// ParseRate parses a rate of the form "count/window", such as "10/1m", where
+// window is in the format accepted by time.ParseDuration.  An empty string
+// parses to the zero Rate.
+func ParseRate(s string) (Rate, error) {
+	if s == "" {
+		return Rate{}, nil
+	}
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Rate{}, fmt.Errorf("rate %q is not of the form count/window", s)
+	}
+	count, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || count <= 0 {
+		return Rate{}, fmt.Errorf("invalid count in rate %q", s)
+	}
+	window, err := time.ParseDuration(parts[1])
+	if err != nil || window <= 0 {
+		return Rate{}, fmt.Errorf("invalid window in rate %q", s)
+	}
+	return Rate{Count: count, Window: window}, nil
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%v", r.Count, r.Window)
+}
+
+// Store holds the counters used by a Limiter.  Implementations must be safe
+// for concurrent use.  To share limits between several instances of a server
+// provide a Store backed by a shared service.
+type Store interface {
+	// Increment adds one to the counter identified by key in the window of the
+	// given length containing now, and returns the new count along with the
+	// time at which the window ends.
+	Increment(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, error)
+	// Decrement undoes an Increment of the counter identified by key in the
+	// window of the given length containing now.  If that window has ended
+	// then nothing is done.
+	Decrement(ctx context.Context, key string, window time.Duration, now time.Time) error
+}
+
+// Options is used with NewLimiter to configure a new Limiter.
+type Options struct {
+	// SubjectRate limits how often any single identity may be issued
+	// credentials.
+	SubjectRate Rate
+	// GlobalRate limits how often credentials may be issued across all
+	// identities.
+	GlobalRate Rate
+	// SubjectDailyQuota limits the number of credentials issued to any single
+	// identity per day.  Zero means no quota.
+	SubjectDailyQuota int64
+	// GlobalDailyQuota limits the number of credentials issued across all
+	// identities per day.  Zero means no quota.
+	GlobalDailyQuota int64
+
+	// Store holds the counters.  If nil, an in-memory store local to the
+	// Limiter is used.
+	Store Store
+	// Now returns the current time.  If nil, time.Now is used.
+	Now func() time.Time
+}
+
+type rule struct {
+	name   string
+	global bool
+	rate   Rate
+}
+
+// Limiter decides whether an identity may be issued a credential.
+type Limiter struct {
+	rules []rule
+	store Store
+	now   func() time.Time
+}
+
+// NewLimiter creates a new Limiter from opts.
+func NewLimiter(opts *Options) *Limiter {
+	l := &Limiter{
+		store: opts.Store,
+		now:   opts.Now,
+	}
+	if l.store == nil {
+		l.store = NewMemoryStore()
+	}
+	if l.now == nil {
+		l.now = time.Now
+	}
+	for _, r := range []rule{
+		{name: "subject-rate", rate: opts.SubjectRate},
+		{name: "global-rate", global: true, rate: opts.GlobalRate},
+		{name: "subject-quota", rate: Rate{Count: opts.SubjectDailyQuota, Window: Day}},
+		{name: "global-quota", global: true, rate: Rate{Count: opts.GlobalDailyQuota, Window: Day}},
+	} {
+		if r.rate.Count > 0 && r.rate.Window > 0 {
+			l.rules = append(l.rules, r)
+		}
+	}
+	return l
+}
+
+// ExceededError is returned by Limiter.Allow when a limit has been reached.
+type ExceededError struct {
+	// Limit names the limit that was exceeded.
+	Limit string
+	// RetryAfter is how long the caller should wait before trying again.
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s limit exceeded, retry after %v", e.Limit, e.RetryAfter)
+}
+
+// Allow records an issuance for the identity with the given issuer and
+// subject.  It returns an *ExceededError if doing so exceeds any of the
+// configured limits, in which case the issuance is not recorded against any
+// of them.
+func (l *Limiter) Allow(ctx context.Context, issuer, subject string) error {
+	now := l.now()
+	var incremented []rule
+	for _, r := range l.rules {
+		count, reset, err := l.store.Increment(ctx, r.key(issuer, subject), r.rate.Window, now)
+		if err != nil {
+			l.undo(ctx, incremented, issuer, subject, now)
+			return fmt.Errorf("incrementing %s counter: %v", r.name, err)
+		}
+		incremented = append(incremented, r)
+		if count > r.rate.Count {
+			l.undo(ctx, incremented, issuer, subject, now)
+			return &ExceededError{Limit: r.name, RetryAfter: reset.Sub(now)}
+		}
+	}
+	return nil
+}
+
+// undo decrements the counters of rules that were incremented by Allow for a
+// rejected issuance.  Failures are ignored, since they only leave the limits
+// stricter than necessary.
+func (l *Limiter) undo(ctx context.Context, rules []rule, issuer, subject string, now time.Time) {
+	for _, r := range rules {
+		l.store.Decrement(ctx, r.key(issuer, subject), r.rate.Window, now)
+	}
+}
+
+// key returns the key of the counter of r for the given identity.  The issuer
+// is length-prefixed since issuers are URLs that may contain any separator,
+// so distinct identities never share a counter.
+func (r rule) key(issuer, subject string) string {
+	if r.global {
+		return r.name
+	}
+	return fmt.Sprintf("%s/%d:%s%s", r.name, len(issuer), issuer, subject)
+}
+
+// Handler implements an http.Handler that enforces a Limiter on the identity
+// associated with each incoming request via ga4gh.NewIdentityContext.
+// Requests exceeding a limit are rejected with HTTP 429 and a Retry-After
+// header.
+type Handler struct {
+	Limiter *Limiter
+	Handler http.Handler
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id, ok := ga4gh.IdentityFromContext(req.Context())
+	if !ok {
+		http.Error(w, "not authorized", http.StatusUnauthorized)
+		return
+	}
+	if err := h.Limiter.Allow(req.Context(), id.Issuer, id.Subject); err != nil {
+		WriteError(w, err)
+		return
+	}
+	h.Handler.ServeHTTP(w, req)
+}
+
+// WriteError writes an HTTP response for an error returned by Limiter.Allow.
+func WriteError(w http.ResponseWriter, err error) {
+	e, ok := err.(*ExceededError)
+	if !ok {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	seconds := int64((e.RetryAfter + time.Second - 1) / time.Second)
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	http.Error(w, "too many requests", http.StatusTooManyRequests)
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Rate
+		err  bool
+	}{
+		{in: "", want: Rate{}},
+		{in: "10/1m", want: Rate{Count: 10, Window: time.Minute}},
+		{in: "3/24h", want: Rate{Count: 3, Window: Day}},
+		{in: "10", err: true},
+		{in: "x/1m", err: true},
+		{in: "0/1m", err: true},
+		{in: "10/forever", err: true},
+	}
+	for _, test := range tests {
+		got, err := ParseRate(test.in)
+		if (err != nil) != test.err {
+			t.Fatalf("ParseRate(%q) error = %v, want error = %v", test.in, err, test.err)
+		}
+		if got != test.want {
+			t.Fatalf("ParseRate(%q) = %v, want = %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(&Options{
+		SubjectRate:       Rate{Count: 2, Window: time.Minute},
+		GlobalRate:        Rate{Count: 3, Window: time.Minute},
+		SubjectDailyQuota: 3,
+		Now:               func() time.Time { return now },
+	})
+	ctx := context.Background()
+
+	steps := []struct {
+		subject string
+		advance time.Duration
+		limit   string
+	}{
+		{subject: "alice"},
+		{subject: "alice"},
+		{subject: "alice", limit: "subject-rate"},
+		{subject: "bob"},
+		{subject: "carol", limit: "global-rate"},
+		{subject: "alice", advance: time.Minute},
+		{subject: "alice", limit: "subject-quota"},
+		{subject: "bob"},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		err := l.Allow(ctx, "https://idp", step.subject)
+		if step.limit == "" {
+			if err != nil {
+				t.Fatalf("step %d: Allow() = %v, want nil", i, err)
+			}
+			continue
+		}
+		e, ok := err.(*ExceededError)
+		if !ok || e.Limit != step.limit {
+			t.Fatalf("step %d: Allow() = %v, want %s exceeded", i, err, step.limit)
+		}
+		if e.RetryAfter <= 0 {
+			t.Fatalf("step %d: RetryAfter = %v, want > 0", i, e.RetryAfter)
+		}
+	}
+}
+
+func TestLimiterRejectionsNotCounted(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(&Options{
+		SubjectRate: Rate{Count: 2, Window: time.Minute},
+		GlobalRate:  Rate{Count: 1, Window: 10 * time.Second},
+		Now:         func() time.Time { return now },
+	})
+	ctx := context.Background()
+
+	if err := l.Allow(ctx, "https://idp", "alice"); err != nil {
+		t.Fatalf("Allow() = %v, want nil", err)
+	}
+	// The second rule rejects this request, so it must not count against the
+	// first.
+	now = now.Add(time.Second)
+	err := l.Allow(ctx, "https://idp", "alice")
+	if e, ok := err.(*ExceededError); !ok || e.Limit != "global-rate" {
+		t.Fatalf("Allow() = %v, want global-rate exceeded", err)
+	}
+	now = now.Add(10 * time.Second)
+	if err := l.Allow(ctx, "https://idp", "alice"); err != nil {
+		t.Fatalf("Allow() after a rejected request = %v, want nil", err)
+	}
+}
+
+func TestLimiterDistinctIdentities(t *testing.T) {
+	l := NewLimiter(&Options{
+		SubjectRate: Rate{Count: 1, Window: time.Minute},
+	})
+	ctx := context.Background()
+
+	// These identities would share a counter if the issuer and subject were
+	// simply joined with "/".
+	if err := l.Allow(ctx, "https://idp/a", "b"); err != nil {
+		t.Fatalf("Allow() = %v, want nil", err)
+	}
+	if err := l.Allow(ctx, "https://idp", "a/b"); err != nil {
+		t.Fatalf("Allow() for a distinct identity = %v, want nil", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := &Handler{
+		Limiter: NewLimiter(&Options{SubjectRate: Rate{Count: 1, Window: time.Hour}}),
+		Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req = req.WithContext(ga4gh.NewIdentityContext(req.Context(), &ga4gh.Identity{Subject: "alice"}))
+
+	for _, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("ServeHTTP() status = %d, want = %d", w.Code, want)
+		}
+	}
+}
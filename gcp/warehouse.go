@@ -101,13 +101,19 @@ func (wh *AccountWarehouse) GetAccountKey(ctx context.Context, id string) ([]byt
 // GetAccessToken returns an access token for the service account uniquely
 // associated with id.
 func (wh *AccountWarehouse) GetAccessToken(ctx context.Context, id string) (string, error) {
+	return wh.GetScopedAccessToken(ctx, id, wh.opts.Scopes)
+}
+
+// GetScopedAccessToken returns an access token with the given scopes for the
+// service account uniquely associated with id.
+func (wh *AccountWarehouse) GetScopedAccessToken(ctx context.Context, id string, scopes []string) (string, error) {
 	account, err := wh.getBackingAccount(ctx, id)
 	if err != nil {
 		return "", fmt.Errorf("getting backing account: %v", err)
 	}
 
 	response, err := wh.creds.Projects.ServiceAccounts.GenerateAccessToken(accountID("-", account), &iamcredentials.GenerateAccessTokenRequest{
-		Scope: wh.opts.Scopes,
+		Scope: scopes,
 	}).Context(ctx).Do()
 	if err != nil {
 		return "", fmt.Errorf("generating access token: %v", err)
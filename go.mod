@@ -15,4 +15,5 @@ require (
 	google.golang.org/api v0.0.0-20180829000535-087779f1d2c9
 	google.golang.org/appengine v1.1.0 // indirect
 	gopkg.in/square/go-jose.v2 v2.1.8
+	gopkg.in/yaml.v3 v3.0.1
 )
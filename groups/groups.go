@@ -0,0 +1,193 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package groups provides a ga4gh.Validator and ga4gh.Enricher for group
+// memberships that are managed in a local file rather than asserted by an
+// identity provider.
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/internal/reloadfile"
+)
+
+// DefaultReloadInterval is the reload interval of a File opened with an
+// interval of zero.
+const DefaultReloadInterval = reloadfile.DefaultInterval
+
+// member identifies an identity by its issuer and subject.
+type member struct {
+	issuer, subject string
+}
+
+// File holds the group memberships listed in a file, which is reloaded when
+// it changes.  Files ending in ".csv" contain records of the form:
+//
+//	issuer,subject,group[,group...]
+//
+// where lines starting with "#" are ignored, while files ending in ".json"
+// contain an array of objects of the form:
+//
+//	{"issuer": "...", "subject": "...", "groups": ["...", ...]}
+//
+// An identity may be listed more than once, in which case it is a member of
+// all of the listed groups.
+type File struct {
+	file *reloadfile.File
+}
+
+// Open loads the group memberships in the file at path.  When the memberships
+// are queried after interval has passed since the file was last read, or
+// DefaultReloadInterval if interval is zero, the file is read again and the
+// memberships are replaced if it has changed.  If the file cannot be read or
+// parsed then the previous memberships remain in use.
+func Open(path string, interval time.Duration) (*File, error) {
+	f, err := reloadfile.Open(path, interval, func(b []byte) (interface{}, error) {
+		return parse(path, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &File{file: f}, nil
+}
+
+// Path returns the path of the file.
+func (f *File) Path() string {
+	return f.file.Path()
+}
+
+// Reload reads the file and, if it has changed, replaces the memberships.  It
+// reports whether the memberships were replaced.
+func (f *File) Reload() (bool, error) {
+	return f.file.Reload()
+}
+
+// Groups returns the groups that the identity with the given issuer and
+// subject is a member of, in sorted order.  The current time is taken from
+// ctx using ga4gh.Now.
+func (f *File) Groups(ctx context.Context, issuer, subject string) []string {
+	members := f.file.Value(ctx).(map[member][]string)
+	return members[member{issuer: issuer, subject: subject}]
+}
+
+// record is a single entry of a group membership file.
+type record struct {
+	Issuer  string   `json:"issuer"`
+	Subject string   `json:"subject"`
+	Groups  []string `json:"groups"`
+}
+
+func parse(path string, b []byte) (map[member][]string, error) {
+	var records []record
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(b, &records); err != nil {
+			return nil, err
+		}
+	case ".csv":
+		r := csv.NewReader(bytes.NewReader(b))
+		r.Comment = '#'
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		for i := 0; ; i++ {
+			fields, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("record %d: want issuer, subject and at least one group", i)
+			}
+			records = append(records, record{Issuer: fields[0], Subject: fields[1], Groups: fields[2:]})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file extension %q", ext)
+	}
+
+	sets := make(map[member]map[string]bool)
+	for i, r := range records {
+		if r.Issuer == "" || r.Subject == "" {
+			return nil, fmt.Errorf("record %d: missing issuer or subject", i)
+		}
+		m := member{issuer: r.Issuer, subject: r.Subject}
+		if sets[m] == nil {
+			sets[m] = make(map[string]bool)
+		}
+		for _, g := range r.Groups {
+			sets[m][g] = true
+		}
+	}
+	members := make(map[member][]string)
+	for m, set := range sets {
+		for g := range set {
+			members[m] = append(members[m], g)
+		}
+		sort.Strings(members[m])
+	}
+	return members, nil
+}
+
+// Validator is a ga4gh.Validator that succeeds if the identity is a member of
+// any of Groups according to File.
+type Validator struct {
+	File   *File
+	Groups []string
+}
+
+// Validate implements the ga4gh.Validator interface.
+func (v *Validator) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	for _, g := range v.File.Groups(ctx, identity.Issuer, identity.Subject) {
+		for _, want := range v.Groups {
+			if g == want {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Enricher is a ga4gh.Enricher that adds the groups that an identity is a
+// member of according to File to its Groups claim, with Source as the source
+// of each value.
+type Enricher struct {
+	File   *File
+	Source string
+}
+
+// Enrich implements the ga4gh.Enricher interface.
+func (e *Enricher) Enrich(ctx context.Context, identity *ga4gh.Identity) (*ga4gh.Identity, error) {
+	groups := e.File.Groups(ctx, identity.Issuer, identity.Subject)
+	if len(groups) == 0 {
+		return identity, nil
+	}
+	enriched := *identity
+	enriched.Groups = append([]ga4gh.StringValue(nil), identity.Groups...)
+	for _, g := range groups {
+		enriched.Groups = append(enriched.Groups, ga4gh.StringValue{Value: g, Source: e.Source})
+	}
+	return &enriched, nil
+}
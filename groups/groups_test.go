@@ -0,0 +1,181 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groups
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+const issuer = "https://idp.example.com"
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Error writing %q: %v", path, err)
+	}
+	return path
+}
+
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "groups")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestFormats(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "groups.csv",
+			content: "# issuer,subject,groups...\n" +
+				issuer + ",alice,cohort-a, admins\n" +
+				issuer + ",alice,cohort-b\n" +
+				issuer + ",bob,cohort-a\n",
+		},
+		{
+			name: "groups.json",
+			content: `[
+				{"issuer": "` + issuer + `", "subject": "alice", "groups": ["cohort-a", "admins"]},
+				{"issuer": "` + issuer + `", "subject": "alice", "groups": ["cohort-b"]},
+				{"issuer": "` + issuer + `", "subject": "bob", "groups": ["cohort-a"]}
+			]`,
+		},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := Open(writeFile(t, dir, test.name, test.content), 0)
+			if err != nil {
+				t.Fatalf("Open() = %v", err)
+			}
+			if got, want := f.Groups(ctx, issuer, "alice"), []string{"admins", "cohort-a", "cohort-b"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("Groups(alice) = %q, want = %q", got, want)
+			}
+			if got := f.Groups(ctx, "https://other.example.com", "alice"); got != nil {
+				t.Fatalf("Groups() for another issuer = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "groups.txt", content: issuer + ",alice,cohort-a\n"},
+		{name: "short.csv", content: issuer + ",alice,cohort-a\n" + issuer + ",bob\n"},
+		{name: "bad.json", content: `{"issuer": "` + issuer + `"}`},
+		{name: "missing.json", content: `[{"subject": "alice", "groups": ["cohort-a"]}]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Open(writeFile(t, dir, test.name, test.content), 0); err == nil {
+				t.Fatal("Open() succeeded, want error")
+			}
+		})
+	}
+	if _, err := Open(filepath.Join(dir, "absent.csv"), 0); err == nil {
+		t.Fatal("Open() of a missing file succeeded, want error")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := writeFile(t, dir, "groups.csv", issuer+",alice,cohort-a\n")
+	f, err := Open(path, time.Minute)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	alice := &ga4gh.Identity{Issuer: issuer, Subject: "alice"}
+	v := &Validator{File: f, Groups: []string{"cohort-b", "cohort-a"}}
+	check := func(want bool) {
+		t.Helper()
+		ok, err := v.Validate(ctx, alice)
+		if err != nil {
+			t.Fatalf("Validate() = %v", err)
+		}
+		if ok != want {
+			t.Fatalf("Validate() = %v, want = %v", ok, want)
+		}
+	}
+
+	check(true)
+	writeFile(t, dir, "groups.csv", issuer+",alice,cohort-c\n")
+	now = now.Add(30 * time.Second)
+	check(true)
+	now = now.Add(30 * time.Second)
+	check(false)
+
+	// A broken file leaves the previous memberships in place.
+	writeFile(t, dir, "groups.csv", issuer+",alice\n")
+	now = now.Add(time.Minute)
+	if got := f.Groups(ctx, issuer, "alice"); !reflect.DeepEqual(got, []string{"cohort-c"}) {
+		t.Fatalf("Groups() after broken reload = %q, want = %q", got, []string{"cohort-c"})
+	}
+}
+
+func TestEnricher(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	f, err := Open(writeFile(t, dir, "groups.csv", issuer+",alice,cohort-a,admins\n"), 0)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	e := &Enricher{File: f, Source: "local"}
+	ctx := context.Background()
+
+	alice := &ga4gh.Identity{Issuer: issuer, Subject: "alice"}
+	got, err := e.Enrich(ctx, alice)
+	if err != nil {
+		t.Fatalf("Enrich() = %v", err)
+	}
+	want := []ga4gh.StringValue{{Value: "admins", Source: "local"}, {Value: "cohort-a", Source: "local"}}
+	if !reflect.DeepEqual(got.Groups, want) {
+		t.Fatalf("Enrich() groups = %+v, want = %+v", got.Groups, want)
+	}
+	if alice.Groups != nil {
+		t.Fatalf("Enrich() modified its input: %+v", alice.Groups)
+	}
+
+	bob := &ga4gh.Identity{Issuer: issuer, Subject: "bob"}
+	if got, err := e.Enrich(ctx, bob); err != nil || got.Groups != nil {
+		t.Fatalf("Enrich(bob) = (%+v, %v), want no groups", got, err)
+	}
+}
@@ -25,7 +25,13 @@ import (
 // with it via NewIdentityContext.
 type Handler struct {
 	// Evaluator is used to provide the parsing and validation logic.
-	Evaluator *Evaluator
+	Evaluator IdentityEvaluator
+
+	// Resource, if set, returns the resource, such as a dataset ID, that a
+	// request accesses.  Requests are then only passed on if the Evaluator
+	// also implements Authorizer and authorizes the identity for the
+	// resource.
+	Resource func(*http.Request) string
 
 	// Handler is invoked only if the incoming identity could be parsed and
 	// validated.  The http.Request will have a ga4gh.Identity associated with it
@@ -47,5 +53,15 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "not authorized", http.StatusUnauthorized)
 		return
 	}
+	if h.Resource != nil {
+		authz, ok := h.Evaluator.(Authorizer)
+		if ok {
+			ok, err = authz.Authorize(ctx, id, h.Resource(req))
+		}
+		if !ok || err != nil {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+	}
 	h.Handler.ServeHTTP(w, req.WithContext(NewIdentityContext(ctx, id)))
 }
@@ -17,16 +17,24 @@
 // Identity workstream.
 package ga4gh
 
-// StringValue represents a string value and claim source.
+// StringValue represents a string value and claim source.  Asserted and
+// Expires are the times, in seconds since the Unix epoch, at which the claim
+// was asserted by its source and after which it is no longer valid; zero means
+// the time is unknown.
 type StringValue struct {
-	Value  string `json:"value"`
-	Source string `json:"source"`
+	Value    string `json:"value"`
+	Source   string `json:"source"`
+	Asserted int64  `json:"asserted,omitempty"`
+	Expires  int64  `json:"expires,omitempty"`
 }
 
-// BoolValue represents a boolean value and claim source.
+// BoolValue represents a boolean value and claim source.  Asserted and Expires
+// are as for StringValue.
 type BoolValue struct {
-	Value  bool   `json:"value"`
-	Source string `json:"source"`
+	Value    bool   `json:"value"`
+	Source   string `json:"source"`
+	Asserted int64  `json:"asserted,omitempty"`
+	Expires  int64  `json:"expires,omitempty"`
 }
 
 // Identity is a GA4GH identity as described by the Data Use and Researcher
@@ -40,4 +48,15 @@ type Identity struct {
 	Role                            []StringValue `json:"ga4gh.Role"`
 	HasAcknowledgedEthicsTerms      []StringValue `json:"ga4gh.HasAcknowledgedEthicsTerms"`
 	BonaFide                        []BoolValue   `json:"ga4gh.ResearcherStatus.BonaFide"`
+
+	// Groups are local group memberships, which are added by an Enricher
+	// rather than asserted by the identity provider.
+	Groups []StringValue `json:"ga4gh.Groups,omitempty"`
+
+	// Visas are the decoded visas of the identity's GA4GH Passport.  They
+	// are never read from or written to JSON, since visas are only trusted
+	// once their signatures have been verified by a shim such as the ELIXIR
+	// shim.  Identities returned by Evaluator only contain active visas; see
+	// ActiveVisas.
+	Visas []Visa `json:"-"`
 }
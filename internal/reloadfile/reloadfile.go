@@ -0,0 +1,113 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reloadfile provides the contents of a local file, parsed by a
+// caller-supplied function and reloaded when the file changes.
+package reloadfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// DefaultInterval is the reload interval of a File opened with an interval of
+// zero.
+const DefaultInterval = 30 * time.Second
+
+// ParseFunc parses the contents of a file.
+type ParseFunc func(b []byte) (interface{}, error)
+
+// File holds the parsed contents of a file, which is reloaded when it
+// changes.
+type File struct {
+	path     string
+	interval time.Duration
+	parse    ParseFunc
+
+	mu      sync.Mutex
+	checked time.Time
+	sum     [sha256.Size]byte
+	loaded  bool
+	value   interface{}
+}
+
+// Open loads the file at path using parse.  When the value is requested after
+// interval has passed since the file was last read, or DefaultInterval if
+// interval is zero, the file is read again and the value is replaced if it has
+// changed.  If the file cannot be read or parsed then the previous value
+// remains in use.
+func Open(path string, interval time.Duration, parse ParseFunc) (*File, error) {
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	f := &File{path: path, interval: interval, parse: parse}
+	if _, err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Path returns the path of the file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Reload reads the file and, if it has changed, replaces the value.  It
+// reports whether the value was replaced.
+func (f *File) Reload() (bool, error) {
+	b, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return false, fmt.Errorf("reading %q: %v", f.path, err)
+	}
+	sum := sha256.Sum256(b)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loaded && sum == f.sum {
+		return false, nil
+	}
+	value, err := f.parse(b)
+	if err != nil {
+		return false, fmt.Errorf("parsing %q: %v", f.path, err)
+	}
+	f.sum, f.value, f.loaded = sum, value, true
+	return true, nil
+}
+
+// Value returns the parsed contents of the file, first reloading it if the
+// reload interval has passed.  The current time is taken from ctx using
+// ga4gh.Now.
+func (f *File) Value(ctx context.Context) interface{} {
+	now := ga4gh.Now(ctx)
+	f.mu.Lock()
+	stale := now.Sub(f.checked) >= f.interval
+	if stale {
+		f.checked = now
+	}
+	f.mu.Unlock()
+	if stale {
+		// Errors leave the previous value in place, as documented by Open.
+		f.Reload()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.value
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reloadfile
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadfile")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "file")
+	write := func(content string) {
+		t.Helper()
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing %q: %v", path, err)
+		}
+	}
+
+	parses := 0
+	parse := func(b []byte) (interface{}, error) {
+		parses++
+		if string(b) == "broken" {
+			return nil, errors.New("broken")
+		}
+		return string(b), nil
+	}
+	if _, err := Open(path, 0, parse); err == nil {
+		t.Fatal("Open() of a missing file succeeded, want error")
+	}
+	write("broken")
+	if _, err := Open(path, 0, parse); err == nil {
+		t.Fatal("Open() of a broken file succeeded, want error")
+	}
+
+	write("a")
+	f, err := Open(path, time.Minute, parse)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	if f.Path() != path {
+		t.Fatalf("Path() = %q, want = %q", f.Path(), path)
+	}
+	if changed, err := f.Reload(); changed || err != nil {
+		t.Fatalf("Reload() of an unchanged file = (%v, %v), want = (false, nil)", changed, err)
+	}
+
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	value := func(want string) {
+		t.Helper()
+		if got := f.Value(ctx); got != want {
+			t.Fatalf("Value() = %v, want = %v", got, want)
+		}
+	}
+
+	value("a")
+	write("b")
+	now = now.Add(30 * time.Second)
+	value("a")
+	now = now.Add(30 * time.Second)
+	value("b")
+
+	// A broken file leaves the previous value in place.
+	write("broken")
+	now = now.Add(time.Minute)
+	value("b")
+	if parses != 4 {
+		t.Fatalf("file parsed %d times, want 4", parses)
+	}
+}
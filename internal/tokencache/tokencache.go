@@ -0,0 +1,87 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokencache provides a bounded cache of values derived from bearer
+// tokens, such as the responses of remote endpoints, that expire.
+package tokencache
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Cache holds values keyed by a SHA-256 hash of a token, which is never
+// stored itself.
+type Cache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*entry
+}
+
+type entry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// New creates a Cache that holds at most maxEntries values.
+func New(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]*entry),
+	}
+}
+
+// Get returns the value cached for token, if there is one that has not
+// expired at now.
+func (c *Cache) Get(token string, now time.Time) (interface{}, bool) {
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Add caches value for token until expires.  When the cache is full, expired
+// entries are removed and, if there are none, an arbitrary entry is.
+func (c *Cache) Add(token string, value interface{}, expires, now time.Time) {
+	if !now.Before(expires) {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	for k := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+	c.entries[key] = &entry{value: value, expires: expires}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokencache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	c := New(2)
+	get := func(token string, want interface{}) {
+		t.Helper()
+		got, ok := c.Get(token, now)
+		if !ok {
+			got = nil
+		}
+		if got != want {
+			t.Fatalf("Get(%q) = %v, want = %v", token, got, want)
+		}
+	}
+
+	c.Add("a", 1, now.Add(time.Minute), now)
+	c.Add("b", 2, now.Add(time.Hour), now)
+	c.Add("expired", 3, now, now)
+	get("a", 1)
+	get("b", 2)
+	get("expired", nil)
+
+	now = now.Add(time.Minute)
+	get("a", nil)
+	get("b", 2)
+
+	// Adding to a full cache removes expired entries before others.
+	c.Add("a", 1, now.Add(time.Minute), now)
+	now = now.Add(90 * time.Second)
+	c.Add("c", 3, now.Add(time.Hour), now)
+	get("b", 2)
+	get("c", 3)
+	c.Add("d", 4, now.Add(time.Hour), now)
+	if len(c.entries) != 2 {
+		t.Fatalf("cache holds %d entries, want 2", len(c.entries))
+	}
+	get("d", 4)
+}
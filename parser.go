@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	oidc "github.com/coreos/go-oidc"
 	"gopkg.in/square/go-jose.v2/jwt"
@@ -33,7 +34,37 @@ type Shim interface {
 // Parser parses OIDC bearer tokens into Identity structs.
 type Parser struct {
 	shims   []Shim
-	issuers map[string]*oidc.IDTokenVerifier
+	issuers map[string]*issuer
+
+	// DenyList, if set, is consulted for every token that is otherwise
+	// successfully parsed.
+	DenyList DenyList
+}
+
+// IssuerOptions control how a Parser verifies the tokens of an issuer.
+type IssuerOptions struct {
+	// Audiences are the client IDs that tokens may be issued to.  A token is
+	// accepted if its aud claim contains any of them.  At least one audience
+	// is required.
+	Audiences []string
+	// Algorithms are the accepted signing algorithms, such as "RS256" or
+	// "ES256".  Defaults to RS256.
+	Algorithms []string
+	// ClockSkew is tolerated when comparing the exp and iat claims of tokens
+	// to the current time.
+	ClockSkew time.Duration
+	// MaxAge, if nonzero, is the longest time after a token was issued, as
+	// given by its iat claim, that it is accepted for.
+	MaxAge time.Duration
+	// RequiredClaims are the names of claims, such as "azp" or "nonce", that
+	// tokens must contain with a non-empty value.
+	RequiredClaims []string
+}
+
+// issuer verifies the tokens of a single issuer.
+type issuer struct {
+	verifier *oidc.IDTokenVerifier
+	options  IssuerOptions
 }
 
 // NewParser constructs a new Parser using shims for translating external
@@ -43,13 +74,40 @@ type Parser struct {
 // the token was issued by any of the OAuth 2.0 providers in issuers, and
 // directly accepting the claims present if it is.
 func NewParser(ctx context.Context, shims []Shim, issuers map[string]string) (*Parser, error) {
-	iss := make(map[string]*oidc.IDTokenVerifier)
-	for issuer, clientID := range issuers {
-		provider, err := oidc.NewProvider(ctx, issuer)
+	options := make(map[string]IssuerOptions)
+	for iss, clientID := range issuers {
+		options[iss] = IssuerOptions{Audiences: []string{clientID}}
+	}
+	return NewParserWithOptions(ctx, shims, options)
+}
+
+// NewParserWithOptions is like NewParser, but verifies the tokens of each of
+// the OAuth 2.0 providers in issuers according to its IssuerOptions.
+func NewParserWithOptions(ctx context.Context, shims []Shim, issuers map[string]IssuerOptions) (*Parser, error) {
+	iss := make(map[string]*issuer)
+	for url, options := range issuers {
+		if len(options.Audiences) == 0 {
+			return nil, fmt.Errorf("no audiences for %q", url)
+		}
+		for _, aud := range options.Audiences {
+			if aud == "" {
+				return nil, fmt.Errorf("empty audience for %q", url)
+			}
+		}
+		provider, err := oidc.NewProvider(ctx, url)
 		if err != nil {
-			return nil, fmt.Errorf("creating provider for %q: %v", issuer, err)
+			return nil, fmt.Errorf("creating provider for %q: %v", url, err)
+		}
+		// The audience and expiry are checked by issuer.verify, which
+		// supports several audiences and clock skew.
+		iss[url] = &issuer{
+			verifier: provider.Verifier(&oidc.Config{
+				SupportedSigningAlgs: options.Algorithms,
+				SkipClientIDCheck:    true,
+				SkipExpiryCheck:      true,
+			}),
+			options: options,
 		}
-		iss[issuer] = provider.Verifier(&oidc.Config{ClientID: clientID})
 	}
 	return &Parser{
 		shims:   shims,
@@ -57,9 +115,74 @@ func NewParser(ctx context.Context, shims []Shim, issuers map[string]string) (*P
 	}, nil
 }
 
+// verify verifies the signature of the token auth and checks its claims
+// against the options of the issuer.  The current time is taken from ctx
+// using Now.
+func (iss *issuer) verify(ctx context.Context, auth string) (*oidc.IDToken, error) {
+	token, err := iss.verifier.Verify(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	o := iss.options
+	if !containsAny(token.Audience, o.Audiences) {
+		return nil, fmt.Errorf("unexpected audience %q", token.Audience)
+	}
+	now := Now(ctx)
+	if token.Expiry.Add(o.ClockSkew).Before(now) {
+		return nil, fmt.Errorf("token expired at %v", token.Expiry)
+	}
+	if o.MaxAge > 0 {
+		if token.IssuedAt.IsZero() {
+			return nil, errors.New("missing iat claim")
+		}
+		if token.IssuedAt.Add(-o.ClockSkew).After(now) {
+			return nil, fmt.Errorf("token issued in the future at %v", token.IssuedAt)
+		}
+		if token.IssuedAt.Add(o.MaxAge + o.ClockSkew).Before(now) {
+			return nil, fmt.Errorf("token issued at %v is older than %v", token.IssuedAt, o.MaxAge)
+		}
+	}
+	if len(o.RequiredClaims) > 0 {
+		var claims map[string]interface{}
+		if err := token.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("extracting claims: %v", err)
+		}
+		for _, name := range o.RequiredClaims {
+			if v, ok := claims[name]; !ok || v == nil || v == "" {
+				return nil, fmt.Errorf("missing required claim %q", name)
+			}
+		}
+	}
+	return token, nil
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, v := range values {
+		for _, w := range wanted {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Parse takes an authorization string (usually an HTTP authorization bearer
-// token) and converts it into an Identity.
+// token) and converts it into an Identity.  It returns ErrDenied if the token
+// is on p.DenyList.
 func (p *Parser) Parse(ctx context.Context, auth string) (*Identity, error) {
+	id, err := p.parse(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkDenyList(ctx, auth, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (p *Parser) parse(ctx context.Context, auth string) (*Identity, error) {
 	for _, shim := range p.shims {
 		id, err := shim.Shim(ctx, auth)
 		if err == nil {
@@ -81,12 +204,12 @@ func (p *Parser) Parse(ctx context.Context, auth string) (*Identity, error) {
 		return nil, fmt.Errorf("extracting base claims: %v", err)
 	}
 
-	verifier, ok := p.issuers[claims.Issuer]
+	iss, ok := p.issuers[claims.Issuer]
 	if !ok {
 		return nil, errors.New("invalid issuer")
 	}
 
-	token, err := verifier.Verify(ctx, auth)
+	token, err := iss.verify(ctx, auth)
 	if err != nil {
 		return nil, fmt.Errorf("verifying token: %v", err)
 	}
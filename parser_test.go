@@ -0,0 +1,181 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// testProvider is a minimal OIDC provider that signs tokens with an RSA key.
+type testProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	p := &testProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.URL,
+			"jwks_uri": p.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key", Algorithm: "RS256", Use: "sig"}},
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+// sign returns a token of the provider with claims and any extra claims.
+func (p *testProvider) sign(t *testing.T, claims jwt.Claims, extra map[string]interface{}) string {
+	t.Helper()
+	key := jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: p.key, KeyID: "key"}}
+	signer, err := jose.NewSigner(key, nil)
+	if err != nil {
+		t.Fatalf("Error creating signer: %v", err)
+	}
+	claims.Issuer = p.URL
+	token, err := jwt.Signed(signer).Claims(claims).Claims(extra).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	return token
+}
+
+func TestParserIssuerOptions(t *testing.T) {
+	p := newTestProvider(t)
+	defer p.Close()
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := NewClockContext(context.Background(), func() time.Time { return now })
+	claims := func(aud string, issued, expires time.Duration) jwt.Claims {
+		return jwt.Claims{
+			Subject:  "alice",
+			Audience: jwt.Audience{aud},
+			IssuedAt: jwt.NewNumericDate(now.Add(issued)),
+			Expiry:   jwt.NewNumericDate(now.Add(expires)),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options IssuerOptions
+		token   string
+		ok      bool
+	}{
+		{
+			name:    "valid",
+			options: IssuerOptions{Audiences: []string{"client"}},
+			token:   p.sign(t, claims("client", -time.Minute, time.Hour), nil),
+			ok:      true,
+		},
+		{
+			name:    "second audience",
+			options: IssuerOptions{Audiences: []string{"client", "other"}},
+			token:   p.sign(t, claims("other", -time.Minute, time.Hour), nil),
+			ok:      true,
+		},
+		{
+			name:    "wrong audience",
+			options: IssuerOptions{Audiences: []string{"client"}},
+			token:   p.sign(t, claims("other", -time.Minute, time.Hour), nil),
+		},
+		{
+			name:    "expired",
+			options: IssuerOptions{Audiences: []string{"client"}},
+			token:   p.sign(t, claims("client", -time.Hour, -time.Second), nil),
+		},
+		{
+			name:    "expired within skew",
+			options: IssuerOptions{Audiences: []string{"client"}, ClockSkew: time.Minute},
+			token:   p.sign(t, claims("client", -time.Hour, -time.Second), nil),
+			ok:      true,
+		},
+		{
+			name:    "too old",
+			options: IssuerOptions{Audiences: []string{"client"}, MaxAge: 30 * time.Minute},
+			token:   p.sign(t, claims("client", -time.Hour, time.Hour), nil),
+		},
+		{
+			name:    "issued in the future",
+			options: IssuerOptions{Audiences: []string{"client"}, MaxAge: 30 * time.Minute},
+			token:   p.sign(t, claims("client", time.Minute, time.Hour), nil),
+		},
+		{
+			name:    "issued in the future within skew",
+			options: IssuerOptions{Audiences: []string{"client"}, MaxAge: 30 * time.Minute, ClockSkew: time.Minute},
+			token:   p.sign(t, claims("client", time.Minute, time.Hour), nil),
+			ok:      true,
+		},
+		{
+			name:    "required claims",
+			options: IssuerOptions{Audiences: []string{"client"}, RequiredClaims: []string{"azp", "nonce"}},
+			token:   p.sign(t, claims("client", -time.Minute, time.Hour), map[string]interface{}{"azp": "client", "nonce": "n"}),
+			ok:      true,
+		},
+		{
+			name:    "missing required claim",
+			options: IssuerOptions{Audiences: []string{"client"}, RequiredClaims: []string{"azp", "nonce"}},
+			token:   p.sign(t, claims("client", -time.Minute, time.Hour), map[string]interface{}{"azp": "client", "nonce": ""}),
+		},
+		{
+			name:    "unsupported algorithm",
+			options: IssuerOptions{Audiences: []string{"client"}, Algorithms: []string{"ES256"}},
+			token:   p.sign(t, claims("client", -time.Minute, time.Hour), nil),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := NewParserWithOptions(ctx, nil, map[string]IssuerOptions{p.URL: test.options})
+			if err != nil {
+				t.Fatalf("NewParserWithOptions() = %v", err)
+			}
+			id, err := parser.Parse(ctx, test.token)
+			if ok := err == nil; ok != test.ok {
+				t.Fatalf("Parse() = %v, want ok = %v", err, test.ok)
+			}
+			if err == nil && id.Subject != "alice" {
+				t.Fatalf("Parse() subject = %q, want = %q", id.Subject, "alice")
+			}
+		})
+	}
+}
+
+func TestNewParserWithOptionsErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, options := range []IssuerOptions{{}, {Audiences: []string{""}}} {
+		if _, err := NewParserWithOptions(ctx, nil, map[string]IssuerOptions{"https://idp.example.com": options}); err == nil {
+			t.Errorf("NewParserWithOptions(%+v) succeeded, want error", options)
+		}
+	}
+}
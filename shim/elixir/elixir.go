@@ -13,23 +13,40 @@
 // limitations under the License.
 
 // Package elixir provides a ga4gh.Shim implementation for translating ELIXIR
-// identities into GA4GH identities.
+// identities into GA4GH identities, and a Decoder for the same claims in
+// UserInfo responses.
 package elixir
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 
 	oidc "github.com/coreos/go-oidc"
 	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 const (
-	issuer = "https://login.elixir-czech.org/oidc/"
+	// Issuer is the OIDC issuer of ELIXIR identities.
+	Issuer = "https://login.elixir-czech.org/oidc/"
 )
 
 // Shim is a ga4gh.Shim that converts ELIXIR identities into GA4GH identities.
+// It maps the following claims:
+//
+//	bona_fide_status              to BonaFide
+//	eduperson_scoped_affiliation  to AcademicInstitutionAffiliations
+//	ga4gh_passport_v1             to Visas
+//
+// Any non-empty bona_fide_status is mapped to a BonaFide value of true whose
+// Source is the issuer, which asserted the status, rather than the URL of the
+// bona fide policy that the claim holds.  Visas are only accepted if they are
+// signed by ELIXIR for the subject of the token; others are logged and
+// ignored.
 type Shim struct {
+	decoder  *Decoder
 	verifier *oidc.IDTokenVerifier
 }
 
@@ -37,20 +54,55 @@ type Shim struct {
 // passed to this shim do not have an audience claim with a value equal to the
 // clientID value then they will be rejected.
 func NewShim(ctx context.Context, clientID string) (*Shim, error) {
-	return newShim(ctx, &oidc.Config{
+	keys, err := remoteKeySet(ctx, Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return newShim(Issuer, keys, &oidc.Config{
 		ClientID: clientID,
-	})
+	}), nil
 }
 
-func newShim(ctx context.Context, config *oidc.Config) (*Shim, error) {
+func newShim(issuer string, keys oidc.KeySet, config *oidc.Config) *Shim {
+	return &Shim{
+		decoder:  &Decoder{issuer: issuer, keys: keys},
+		verifier: oidc.NewVerifier(issuer, keys, config),
+	}
+}
+
+// remoteKeySet returns the signing keys published by issuer.
+func remoteKeySet(ctx context.Context, issuer string) (oidc.KeySet, error) {
 	provider, err := oidc.NewProvider(ctx, issuer)
 	if err != nil {
 		return nil, fmt.Errorf("creating provider: %v", err)
 	}
+	var claims struct {
+		JWKSURL string `json:"jwks_uri"`
+	}
+	if err := provider.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("reading provider configuration: %v", err)
+	}
+	return oidc.NewRemoteKeySet(ctx, claims.JWKSURL), nil
+}
 
-	return &Shim{
-		verifier: provider.Verifier(config),
-	}, nil
+// stringList is a claim that is either a single string or a list of them.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(l))
+}
+
+// userClaims are the ELIXIR claims of a token or UserInfo response.
+type userClaims struct {
+	Subject      string     `json:"sub"`
+	BonaFide     string     `json:"bona_fide_status"`
+	Affiliations stringList `json:"eduperson_scoped_affiliation"`
+	Passport     []string   `json:"ga4gh_passport_v1"`
 }
 
 // Shim implements the ga4gh.Shim interface.
@@ -59,25 +111,115 @@ func (s *Shim) Shim(ctx context.Context, auth string) (*ga4gh.Identity, error) {
 	if err != nil {
 		return nil, fmt.Errorf("verifying token: %v", err)
 	}
-	var claims struct {
-		BonaFide *string `json:"bona_fide_status"`
-	}
-	if err := token.Claims(&claims); err != nil {
+	var c userClaims
+	if err := token.Claims(&c); err != nil {
 		return nil, fmt.Errorf("getting claims: %v", err)
 	}
+	var asserted int64
+	if !token.IssuedAt.IsZero() {
+		asserted = token.IssuedAt.Unix()
+	}
+	return s.decoder.identity(ctx, &c, asserted), nil
+}
 
+// Decoder converts the ELIXIR claims returned by the UserInfo endpoint of an
+// issuer, which may release them there rather than in its tokens, into GA4GH
+// identities.  It maps the same claims as Shim, and only accepts visas that
+// are signed by the issuer for the subject of the response.  Its Decode method
+// can be used as a userinfo.Decoder.
+type Decoder struct {
+	issuer string
+	keys   oidc.KeySet
+}
+
+// NewDecoder creates a new Decoder for the UserInfo responses of issuer, such
+// as Issuer.
+func NewDecoder(ctx context.Context, issuer string) (*Decoder, error) {
+	keys, err := remoteKeySet(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &Decoder{issuer: issuer, keys: keys}, nil
+}
+
+// Decode converts a UserInfo response into an identity.  Since UserInfo
+// responses have no assertion time, the claims are treated as asserted at the
+// current time, which is taken from ctx using ga4gh.Now.
+func (d *Decoder) Decode(ctx context.Context, b []byte) (*ga4gh.Identity, error) {
+	var c userClaims
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("no subject")
+	}
+	return d.identity(ctx, &c, ga4gh.Now(ctx).Unix()), nil
+}
+
+// identity converts the claims c, asserted at the given time in seconds since
+// the Unix epoch, into an identity.
+func (d *Decoder) identity(ctx context.Context, c *userClaims, asserted int64) *ga4gh.Identity {
 	id := ga4gh.Identity{
-		Issuer:  token.Issuer,
-		Subject: token.Subject,
+		Issuer:  d.issuer,
+		Subject: c.Subject,
 	}
-	if claims.BonaFide != nil {
+	if c.BonaFide != "" {
 		id.BonaFide = []ga4gh.BoolValue{
 			{
-				Value:  true,
-				Source: issuer,
+				Value:    true,
+				Source:   d.issuer,
+				Asserted: asserted,
 			},
 		}
 	}
+	for _, a := range c.Affiliations {
+		if a == "" {
+			continue
+		}
+		id.AcademicInstitutionAffiliations = append(id.AcademicInstitutionAffiliations, ga4gh.StringValue{
+			Value:    a,
+			Source:   d.issuer,
+			Asserted: asserted,
+		})
+	}
+	for i, v := range c.Passport {
+		// Visas that cannot be verified are ignored rather than failing the
+		// whole token, since they may be signed by other brokers.
+		visa, err := d.visa(ctx, v, c.Subject)
+		if err != nil {
+			log.Printf("Ignoring visa %d of %q: %v", i, c.Subject, err)
+			continue
+		}
+		id.Visas = append(id.Visas, *visa)
+	}
+	return &id
+}
 
-	return &id, nil
+// visa verifies the visa JWT v, which must be signed by the issuer for
+// subject, and decodes it.
+func (d *Decoder) visa(ctx context.Context, v, subject string) (*ga4gh.Visa, error) {
+	payload, err := d.keys.VerifySignature(ctx, v)
+	if err != nil {
+		return nil, fmt.Errorf("verifying visa: %v", err)
+	}
+	var claims struct {
+		jwt.Claims
+		Visa *ga4gh.Visa `json:"ga4gh_visa_v1"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("decoding visa: %v", err)
+	}
+	switch {
+	case claims.Issuer != d.issuer:
+		return nil, fmt.Errorf("visa issued by %q", claims.Issuer)
+	case claims.Subject != subject:
+		return nil, fmt.Errorf("visa for subject %q", claims.Subject)
+	case claims.Visa == nil || claims.Visa.Type == "":
+		return nil, fmt.Errorf("no ga4gh_visa_v1 claim")
+	}
+	visa := *claims.Visa
+	if claims.Expiry != 0 {
+		visa.Expires = claims.Expiry.Time().Unix()
+	}
+	return &visa, nil
 }
@@ -0,0 +1,187 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elixir
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"reflect"
+	"testing"
+	"time"
+
+	oidc "github.com/coreos/go-oidc"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// keySet is an oidc.KeySet of a single key.
+type keySet struct {
+	key *rsa.PublicKey
+}
+
+func (k *keySet) VerifySignature(ctx context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, err
+	}
+	return jws.Verify(k.key)
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	return key
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, claims ...interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	if err != nil {
+		t.Fatalf("Error creating signer: %v", err)
+	}
+	builder := jwt.Signed(signer)
+	for _, c := range claims {
+		builder = builder.Claims(c)
+	}
+	token, err := builder.CompactSerialize()
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	return token
+}
+
+func TestShim(t *testing.T) {
+	key, otherKey := generateKey(t), generateKey(t)
+	s := newShim(Issuer, &keySet{key: &key.PublicKey}, &oidc.Config{ClientID: "client"})
+
+	issued := time.Now().Add(-time.Minute).Truncate(time.Second)
+	base := jwt.Claims{
+		Issuer:   Issuer,
+		Subject:  "alice@elixir-europe.org",
+		Audience: jwt.Audience{"client"},
+		IssuedAt: jwt.NewNumericDate(issued),
+		Expiry:   jwt.NewNumericDate(issued.Add(time.Hour)),
+	}
+	visaExpiry := issued.Add(24 * time.Hour)
+	visa := func(key *rsa.PrivateKey, issuer, subject string) string {
+		return sign(t, key, jwt.Claims{
+			Issuer:  issuer,
+			Subject: subject,
+			Expiry:  jwt.NewNumericDate(visaExpiry),
+		}, map[string]interface{}{
+			"ga4gh_visa_v1": map[string]interface{}{
+				"type":     "ControlledAccessGrants",
+				"asserted": issued.Add(-time.Hour).Unix(),
+				"value":    "https://ega-archive.org/datasets/EGAD00000000001",
+				"source":   "https://ega-archive.org/dacs/EGAC00000000001",
+				"by":       "dac",
+				"conditions": [][]map[string]string{{
+					{"type": "AffiliationAndRole", "value": "pattern:*@uni.example.org"},
+				}},
+			},
+		})
+	}
+	grant := ga4gh.Visa{
+		Type:       "ControlledAccessGrants",
+		Asserted:   issued.Add(-time.Hour).Unix(),
+		Expires:    visaExpiry.Unix(),
+		Value:      "https://ega-archive.org/datasets/EGAD00000000001",
+		Source:     "https://ega-archive.org/dacs/EGAC00000000001",
+		By:         "dac",
+		Conditions: [][]ga4gh.Condition{{{Type: "AffiliationAndRole", Value: "pattern:*@uni.example.org"}}},
+	}
+	identity := func(modify func(id *ga4gh.Identity)) *ga4gh.Identity {
+		id := &ga4gh.Identity{Issuer: Issuer, Subject: base.Subject}
+		if modify != nil {
+			modify(id)
+		}
+		return id
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		want   *ga4gh.Identity
+	}{
+		{
+			name: "no claims",
+			want: identity(nil),
+		},
+		{
+			name:   "bona fide",
+			claims: map[string]interface{}{"bona_fide_status": "https://doi.org/10.1038/s41431-018-0219-y"},
+			want: identity(func(id *ga4gh.Identity) {
+				id.BonaFide = []ga4gh.BoolValue{{Value: true, Source: Issuer, Asserted: issued.Unix()}}
+			}),
+		},
+		{
+			name:   "empty bona fide",
+			claims: map[string]interface{}{"bona_fide_status": ""},
+			want:   identity(nil),
+		},
+		{
+			name:   "affiliations",
+			claims: map[string]interface{}{"eduperson_scoped_affiliation": []string{"faculty@uni.example.org", "", "member@uni.example.org"}},
+			want: identity(func(id *ga4gh.Identity) {
+				id.AcademicInstitutionAffiliations = []ga4gh.StringValue{
+					{Value: "faculty@uni.example.org", Source: Issuer, Asserted: issued.Unix()},
+					{Value: "member@uni.example.org", Source: Issuer, Asserted: issued.Unix()},
+				}
+			}),
+		},
+		{
+			name:   "single affiliation",
+			claims: map[string]interface{}{"eduperson_scoped_affiliation": "faculty@uni.example.org"},
+			want: identity(func(id *ga4gh.Identity) {
+				id.AcademicInstitutionAffiliations = []ga4gh.StringValue{{Value: "faculty@uni.example.org", Source: Issuer, Asserted: issued.Unix()}}
+			}),
+		},
+		{
+			name: "passport",
+			claims: map[string]interface{}{"ga4gh_passport_v1": []string{
+				visa(key, Issuer, base.Subject),
+				visa(otherKey, Issuer, base.Subject),
+				visa(key, "https://other.example.org/", base.Subject),
+				visa(key, Issuer, "bob@elixir-europe.org"),
+				sign(t, key, jwt.Claims{Issuer: Issuer, Subject: base.Subject}),
+				"not a jwt",
+			}},
+			want: identity(func(id *ga4gh.Identity) {
+				id.Visas = []ga4gh.Visa{grant}
+			}),
+		},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, err := s.Shim(ctx, sign(t, key, base, test.claims))
+			if err != nil {
+				t.Fatalf("Shim() = %v", err)
+			}
+			if !reflect.DeepEqual(id, test.want) {
+				t.Fatalf("Shim() = %+v, want = %+v", id, test.want)
+			}
+		})
+	}
+
+	if _, err := s.Shim(ctx, sign(t, otherKey, base)); err == nil {
+		t.Fatal("Shim() of a token with an invalid signature succeeded, want error")
+	}
+}
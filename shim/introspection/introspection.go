@@ -0,0 +1,205 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package introspection provides a ga4gh.Shim implementation for opaque
+// access tokens, which are validated by the OAuth 2.0 token introspection
+// endpoint of their issuer as described by RFC 7662.
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/internal/tokencache"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// DefaultTimeout is the timeout of introspection requests made by a Shim
+	// created with a zero Timeout.
+	DefaultTimeout = 10 * time.Second
+	// DefaultCacheEntries is the number of results held by a Shim created
+	// with a zero CacheEntries.
+	DefaultCacheEntries = 10000
+)
+
+// Config describes an introspection endpoint and how to authenticate to it.
+type Config struct {
+	// Endpoint is the URL of the introspection endpoint.  It must use https
+	// unless its host is a loopback address.
+	Endpoint string
+	// Issuer is the issuer of the introspected tokens, which is used as the
+	// issuer of their identities.  Responses naming another issuer are
+	// rejected.
+	Issuer string
+	// ClientID and ClientSecret are the credentials of the client, which are
+	// sent using HTTP basic authentication.  Responses must name ClientID as
+	// their client_id or in their aud.
+	ClientID     string
+	ClientSecret string
+	// Timeout limits the duration of each introspection request.  Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+	// CacheEntries is the most results to cache.  Defaults to
+	// DefaultCacheEntries.
+	CacheEntries int
+}
+
+// Shim is a ga4gh.Shim that converts opaque access tokens into GA4GH
+// identities by introspecting them.  Tokens that are active according to the
+// endpoint are cached until their expiry, while inactive tokens and tokens
+// without an expiry are not cached.  JWTs are not introspected, so that they
+// are left for the issuers of a ga4gh.Parser to verify.
+//
+// Opaque tokens carry no indication of their issuer, so every opaque token
+// presented to a Shim is sent to its endpoint, including tokens issued by
+// other identity providers when a ga4gh.Parser has several shims.  Only
+// configure endpoints that may be trusted with all such tokens.
+type Shim struct {
+	config Config
+	client *http.Client
+	cache  *tokencache.Cache
+}
+
+// NewShim creates a new Shim for the introspection endpoint described by
+// config.
+func NewShim(config Config) (*Shim, error) {
+	u, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint: %v", err)
+	}
+	if u.Host == "" || u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname())) {
+		return nil, fmt.Errorf("endpoint %q is not an https URL", config.Endpoint)
+	}
+	if config.Issuer == "" {
+		return nil, errors.New("no issuer")
+	}
+	if config.ClientID == "" {
+		return nil, errors.New("no client ID")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.CacheEntries <= 0 {
+		config.CacheEntries = DefaultCacheEntries
+	}
+	return &Shim{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		cache:  tokencache.New(config.CacheEntries),
+	}, nil
+}
+
+// isLoopback reports whether host names the local machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// response is an introspection response as described by RFC 7662 section 2.2.
+type response struct {
+	Active   bool         `json:"active"`
+	Subject  string       `json:"sub"`
+	Issuer   string       `json:"iss"`
+	Expiry   *float64     `json:"exp"`
+	ClientID string       `json:"client_id"`
+	Audience jwt.Audience `json:"aud"`
+}
+
+// issuedTo reports whether the response names clientID as its client or
+// audience.
+func (r *response) issuedTo(clientID string) bool {
+	return r.ClientID == clientID || r.Audience.Contains(clientID)
+}
+
+// Shim implements the ga4gh.Shim interface.  The current time is taken from
+// ctx using ga4gh.Now.
+func (s *Shim) Shim(ctx context.Context, auth string) (*ga4gh.Identity, error) {
+	if _, err := jwt.ParseSigned(auth); err == nil {
+		return nil, errors.New("not an opaque token")
+	}
+
+	now := ga4gh.Now(ctx)
+	if id, ok := s.cache.Get(auth, now); ok {
+		return id.(*ga4gh.Identity), nil
+	}
+
+	resp, err := s.introspect(ctx, auth)
+	if err != nil {
+		return nil, fmt.Errorf("introspecting token: %v", err)
+	}
+	if !resp.Active {
+		return nil, errors.New("token is not active")
+	}
+	if resp.Issuer != "" && resp.Issuer != s.config.Issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", resp.Issuer)
+	}
+	if !resp.issuedTo(s.config.ClientID) {
+		return nil, fmt.Errorf("token was not issued to client %q", s.config.ClientID)
+	}
+	if resp.Subject == "" {
+		return nil, errors.New("no subject in introspection response")
+	}
+	id := &ga4gh.Identity{Issuer: s.config.Issuer, Subject: resp.Subject}
+	if resp.Expiry != nil {
+		expires := time.Unix(int64(*resp.Expiry), 0)
+		if !now.Before(expires) {
+			return nil, fmt.Errorf("token expired at %v", expires)
+		}
+		s.cache.Add(auth, id, expires, now)
+	}
+	return id, nil
+}
+
+func (s *Shim) introspect(ctx context.Context, auth string) (*response, error) {
+	form := url.Values{"token": {auth}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequest(http.MethodPost, s.config.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// RFC 6749 section 2.3.1 requires the credentials to be form encoded
+	// before they are used for basic authentication.
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	r, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, r.Body)
+		return nil, fmt.Errorf("unexpected status %q", r.Status)
+	}
+	var resp response
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decoding response: %v", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const issuer = "https://idp.example.com"
+
+var now = time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+
+// newEndpoint returns an introspection endpoint with the given responses,
+// keyed by token, and a count of the requests it has received.
+func newEndpoint(responses map[string]interface{}) (*httptest.Server, *int) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "s%3Dcret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		resp, ok := responses[r.PostFormValue("token")]
+		if !ok {
+			resp = map[string]interface{}{"active": false}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	return srv, &calls
+}
+
+func TestShim(t *testing.T) {
+	exp := now.Add(time.Hour).Unix()
+	srv, calls := newEndpoint(map[string]interface{}{
+		"valid":          map[string]interface{}{"active": true, "sub": "alice", "iss": issuer, "exp": exp, "client_id": "client"},
+		"audience":       map[string]interface{}{"active": true, "sub": "alice", "exp": exp, "aud": []string{"other", "client"}},
+		"other client":   map[string]interface{}{"active": true, "sub": "alice", "exp": exp, "client_id": "other", "aud": "other"},
+		"no client":      map[string]interface{}{"active": true, "sub": "alice", "exp": exp},
+		"no issuer":      map[string]interface{}{"active": true, "sub": "alice", "exp": exp, "client_id": "client"},
+		"other issuer":   map[string]interface{}{"active": true, "sub": "alice", "iss": "https://other.example.com", "exp": exp, "client_id": "client"},
+		"no subject":     map[string]interface{}{"active": true, "iss": issuer, "exp": exp, "client_id": "client"},
+		"expired":        map[string]interface{}{"active": true, "sub": "alice", "exp": now.Unix(), "client_id": "client"},
+		"no expiry":      map[string]interface{}{"active": true, "sub": "alice", "client_id": "client"},
+		"inactive":       map[string]interface{}{"active": false, "sub": "alice", "exp": exp, "client_id": "client"},
+		"malformed json": "active",
+	})
+	defer srv.Close()
+
+	tests := []struct {
+		token string
+		ok    bool
+	}{
+		{token: "valid", ok: true},
+		{token: "audience", ok: true},
+		{token: "other client"},
+		{token: "no client"},
+		{token: "no issuer", ok: true},
+		{token: "other issuer"},
+		{token: "no subject"},
+		{token: "expired"},
+		{token: "no expiry", ok: true},
+		{token: "inactive"},
+		{token: "unknown"},
+		{token: "malformed json"},
+	}
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	for _, test := range tests {
+		t.Run(test.token, func(t *testing.T) {
+			s, err := NewShim(Config{Endpoint: srv.URL, Issuer: issuer, ClientID: "client", ClientSecret: "s=cret"})
+			if err != nil {
+				t.Fatalf("NewShim() = %v", err)
+			}
+			id, err := s.Shim(ctx, test.token)
+			if ok := err == nil; ok != test.ok {
+				t.Fatalf("Shim() = %v, want ok = %v", err, test.ok)
+			}
+			if err != nil {
+				return
+			}
+			if want := (&ga4gh.Identity{Issuer: issuer, Subject: "alice"}); !reflect.DeepEqual(id, want) {
+				t.Fatalf("Shim() = %+v, want = %+v", id, want)
+			}
+		})
+	}
+
+	s, err := NewShim(Config{Endpoint: srv.URL, Issuer: issuer, ClientID: "client", ClientSecret: "wrong"})
+	if err != nil {
+		t.Fatalf("NewShim() = %v", err)
+	}
+	*calls = 0
+	if _, err := s.Shim(ctx, "valid"); err == nil || *calls != 1 {
+		t.Fatalf("Shim() with wrong credentials = %v after %d calls, want error after 1 call", err, *calls)
+	}
+}
+
+func TestShimCache(t *testing.T) {
+	srv, calls := newEndpoint(map[string]interface{}{
+		"a":         map[string]interface{}{"active": true, "sub": "alice", "exp": now.Add(time.Hour).Unix(), "client_id": "client"},
+		"b":         map[string]interface{}{"active": true, "sub": "bob", "exp": now.Add(time.Hour).Unix(), "client_id": "client"},
+		"no expiry": map[string]interface{}{"active": true, "sub": "alice", "client_id": "client"},
+	})
+	defer srv.Close()
+	s, err := NewShim(Config{Endpoint: srv.URL, Issuer: issuer, ClientID: "client", ClientSecret: "s=cret", CacheEntries: 1})
+	if err != nil {
+		t.Fatalf("NewShim() = %v", err)
+	}
+
+	clock := now
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return clock })
+	shim := func(token string, wantCalls int) {
+		t.Helper()
+		if _, err := s.Shim(ctx, token); err != nil {
+			t.Fatalf("Shim(%q) = %v", token, err)
+		}
+		if *calls != wantCalls {
+			t.Fatalf("after Shim(%q) the endpoint was called %d times, want %d", token, *calls, wantCalls)
+		}
+	}
+
+	shim("a", 1)
+	shim("a", 1)
+	shim("no expiry", 2)
+	shim("no expiry", 3)
+	// The cache holds one entry, so adding b evicts a.
+	shim("b", 4)
+	shim("a", 5)
+	clock = clock.Add(time.Hour)
+	if _, err := s.Shim(ctx, "a"); err == nil {
+		t.Fatal("Shim() of an expired cached token succeeded, want error")
+	}
+}
+
+func TestShimSkipsJWTs(t *testing.T) {
+	srv, calls := newEndpoint(nil)
+	defer srv.Close()
+	s, err := NewShim(Config{Endpoint: srv.URL, Issuer: issuer, ClientID: "client"})
+	if err != nil {
+		t.Fatalf("NewShim() = %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+	if err != nil {
+		t.Fatalf("Error creating signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "alice"}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	if _, err := s.Shim(context.Background(), token); err == nil || *calls != 0 {
+		t.Fatalf("Shim() of a JWT = %v after %d calls, want error without calls", err, *calls)
+	}
+}
+
+func TestNewShimErrors(t *testing.T) {
+	for _, config := range []Config{
+		{Endpoint: "idp.example.com/introspect", Issuer: issuer, ClientID: "client"},
+		{Endpoint: "http://idp.example.com/introspect", Issuer: issuer, ClientID: "client"},
+		{Endpoint: issuer + "/introspect", ClientID: "client"},
+		{Endpoint: issuer + "/introspect", Issuer: issuer},
+	} {
+		if _, err := NewShim(config); err == nil {
+			t.Errorf("NewShim(%+v) succeeded, want error", config)
+		}
+	}
+}
+
+func TestNewShimLoopback(t *testing.T) {
+	for _, endpoint := range []string{
+		"http://localhost:8080/introspect",
+		"http://127.0.0.1/introspect",
+		"http://[::1]/introspect",
+	} {
+		if _, err := NewShim(Config{Endpoint: endpoint, Issuer: issuer, ClientID: "client"}); err != nil {
+			t.Errorf("NewShim(%q) = %v, want nil", endpoint, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package userinfo provides a ga4gh.Enricher that adds the claims returned by
+// the OIDC UserInfo endpoint of an identity's issuer, which some identity
+// providers only release there rather than in their tokens.
+package userinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	oidc "github.com/coreos/go-oidc"
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/internal/tokencache"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// DefaultTimeout is the timeout of UserInfo requests made by an Enricher
+	// created with a zero Timeout.
+	DefaultTimeout = 10 * time.Second
+	// DefaultCacheTTL is the longest time for which an Enricher created with a
+	// zero CacheTTL caches a response.
+	DefaultCacheTTL = 5 * time.Minute
+	// DefaultCacheEntries is the number of responses held by an Enricher
+	// created with a zero CacheEntries.
+	DefaultCacheEntries = 10000
+)
+
+// Decoder converts the claims returned by a UserInfo endpoint into an
+// Identity, like the Decode method of elixir.Decoder.
+type Decoder func(ctx context.Context, claims []byte) (*ga4gh.Identity, error)
+
+// Config describes the UserInfo endpoint of an issuer.
+type Config struct {
+	// Issuer is the OIDC issuer whose identities are enriched.  Identities of
+	// other issuers are left unchanged.
+	Issuer string
+	// Endpoint is the URL of the UserInfo endpoint.  If empty, it is
+	// discovered from the issuer's OIDC configuration.
+	Endpoint string
+	// Decode converts the returned claims into an Identity.  If nil, the
+	// claims are expected to be in the JSON format of ga4gh.Identity, from
+	// which visas are never read.
+	Decode Decoder
+	// Timeout limits the duration of each request.  Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+	// CacheTTL is the longest time to cache a response for.  Responses are
+	// never cached beyond the expiry of the token, if it is a JWT.  Defaults
+	// to DefaultCacheTTL.
+	CacheTTL time.Duration
+	// CacheEntries is the most responses to cache.  Defaults to
+	// DefaultCacheEntries.
+	CacheEntries int
+}
+
+// Enricher is a ga4gh.Enricher that calls the UserInfo endpoint of an issuer
+// with the authorization string being evaluated, which must be an access token
+// of the identity, and adds the returned claims to the identity.  Groups are
+// never added, since they are only trusted from local sources.  It must be
+// used by a ga4gh.Evaluator, which makes the authorization string available
+// through ga4gh.AuthorizationFromContext.
+type Enricher struct {
+	config Config
+	client *http.Client
+	cache  *tokencache.Cache
+}
+
+// NewEnricher creates a new Enricher for the UserInfo endpoint described by
+// config.
+func NewEnricher(ctx context.Context, config Config) (*Enricher, error) {
+	if config.Issuer == "" {
+		return nil, errors.New("no issuer")
+	}
+	if config.Endpoint == "" {
+		provider, err := oidc.NewProvider(ctx, config.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("creating provider for %q: %v", config.Issuer, err)
+		}
+		var claims struct {
+			Endpoint string `json:"userinfo_endpoint"`
+		}
+		if err := provider.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("reading configuration of %q: %v", config.Issuer, err)
+		}
+		if claims.Endpoint == "" {
+			return nil, fmt.Errorf("%q has no UserInfo endpoint", config.Issuer)
+		}
+		config.Endpoint = claims.Endpoint
+	}
+	if config.Decode == nil {
+		config.Decode = decode
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = DefaultCacheTTL
+	}
+	if config.CacheEntries <= 0 {
+		config.CacheEntries = DefaultCacheEntries
+	}
+	return &Enricher{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		cache:  tokencache.New(config.CacheEntries),
+	}, nil
+}
+
+// Endpoint returns the URL of the UserInfo endpoint.
+func (e *Enricher) Endpoint() string {
+	return e.config.Endpoint
+}
+
+// Enrich implements the ga4gh.Enricher interface.  The current time is taken
+// from ctx using ga4gh.Now.
+func (e *Enricher) Enrich(ctx context.Context, identity *ga4gh.Identity) (*ga4gh.Identity, error) {
+	if identity.Issuer != e.config.Issuer {
+		return identity, nil
+	}
+	auth, ok := ga4gh.AuthorizationFromContext(ctx)
+	if !ok {
+		return nil, errors.New("no authorization in context")
+	}
+
+	now := ga4gh.Now(ctx)
+	var info *ga4gh.Identity
+	if cached, ok := e.cache.Get(auth, now); ok {
+		info = cached.(*ga4gh.Identity)
+	} else {
+		claims, err := e.fetch(ctx, auth)
+		if err != nil {
+			return nil, fmt.Errorf("fetching user info: %v", err)
+		}
+		if info, err = e.config.Decode(ctx, claims); err != nil {
+			return nil, fmt.Errorf("decoding user info: %v", err)
+		}
+		e.cache.Add(auth, info, e.expiry(auth, now), now)
+	}
+	// OIDC Core section 5.3.2 requires the subject to be checked, since the
+	// response may otherwise be for another user.
+	if info.Subject != identity.Subject {
+		return nil, fmt.Errorf("user info is for subject %q, want %q", info.Subject, identity.Subject)
+	}
+
+	enriched := *identity
+	enriched.OriginOrganization = appendStrings(identity.OriginOrganization, info.OriginOrganization)
+	enriched.AcademicInstitutionAffiliations = appendStrings(identity.AcademicInstitutionAffiliations, info.AcademicInstitutionAffiliations)
+	enriched.Role = appendStrings(identity.Role, info.Role)
+	enriched.HasAcknowledgedEthicsTerms = appendStrings(identity.HasAcknowledgedEthicsTerms, info.HasAcknowledgedEthicsTerms)
+	enriched.BonaFide = append(append([]ga4gh.BoolValue(nil), identity.BonaFide...), info.BonaFide...)
+	enriched.Visas = append(append([]ga4gh.Visa(nil), identity.Visas...), info.Visas...)
+	return &enriched, nil
+}
+
+func (e *Enricher) fetch(ctx context.Context, auth string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, e.config.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+auth)
+	req.Header.Set("Accept", "application/json")
+
+	r, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, r.Body)
+		return nil, fmt.Errorf("unexpected status %q", r.Status)
+	}
+	return ioutil.ReadAll(r.Body)
+}
+
+// expiry returns the time until which the user info of auth, fetched at now,
+// may be cached.
+func (e *Enricher) expiry(auth string, now time.Time) time.Time {
+	expires := now.Add(e.config.CacheTTL)
+	if parsed, err := jwt.ParseSigned(auth); err == nil {
+		var claims jwt.Claims
+		if err := parsed.UnsafeClaimsWithoutVerification(&claims); err == nil && claims.Expiry != 0 && claims.Expiry.Time().Before(expires) {
+			expires = claims.Expiry.Time()
+		}
+	}
+	return expires
+}
+
+func decode(ctx context.Context, claims []byte) (*ga4gh.Identity, error) {
+	var id ga4gh.Identity
+	if err := json.Unmarshal(claims, &id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func appendStrings(dst, src []ga4gh.StringValue) []ga4gh.StringValue {
+	return append(append([]ga4gh.StringValue(nil), dst...), src...)
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userinfo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+	"github.com/googlegenomics/ga4gh-identity/shim/elixir"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+var now = time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+
+// newServer returns an OIDC provider whose UserInfo endpoint returns the
+// given claims, keyed by access token, and a count of the requests to it.
+func newServer(claims map[string]string) (*httptest.Server, *int) {
+	calls := 0
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":            srv.URL,
+			"userinfo_endpoint": srv.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		c, ok := claims[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if !ok {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(c))
+	})
+	srv = httptest.NewServer(mux)
+	return srv, &calls
+}
+
+func TestEnrich(t *testing.T) {
+	srv, _ := newServer(map[string]string{
+		"alice": `{
+			"sub": "alice",
+			"ga4gh.AcademicInstitutionAffiliations": [{"value": "faculty@uni.example.org", "source": "uni"}],
+			"ga4gh.Groups": [{"value": "admins", "source": "forged"}],
+			"ga4gh.Visas": [{"type": "ResearcherStatus", "value": "https://doi.org/10.1038/s41431-018-0219-y", "source": "forged"}]
+		}`,
+		"other subject": `{"sub": "bob"}`,
+		"malformed":     `{"sub": `,
+	})
+	defer srv.Close()
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	e, err := NewEnricher(ctx, Config{Issuer: srv.URL})
+	if err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+	if got, want := e.Endpoint(), srv.URL+"/userinfo"; got != want {
+		t.Fatalf("Endpoint() = %q, want = %q", got, want)
+	}
+	alice := &ga4gh.Identity{
+		Issuer:                          srv.URL,
+		Subject:                         "alice",
+		AcademicInstitutionAffiliations: []ga4gh.StringValue{{Value: "student@uni.example.org", Source: "token"}},
+	}
+
+	enriched, err := e.Enrich(ga4gh.NewAuthorizationContext(ctx, "alice"), alice)
+	if err != nil {
+		t.Fatalf("Enrich() = %v", err)
+	}
+	want := *alice
+	want.AcademicInstitutionAffiliations = []ga4gh.StringValue{
+		{Value: "student@uni.example.org", Source: "token"},
+		{Value: "faculty@uni.example.org", Source: "uni"},
+	}
+	if !reflect.DeepEqual(enriched, &want) {
+		t.Fatalf("Enrich() = %+v, want = %+v", enriched, &want)
+	}
+	if len(alice.AcademicInstitutionAffiliations) != 1 {
+		t.Fatalf("Enrich() modified its input: %+v", alice.AcademicInstitutionAffiliations)
+	}
+
+	other := &ga4gh.Identity{Issuer: "https://other.example.com", Subject: "alice"}
+	if got, err := e.Enrich(ctx, other); err != nil || got != other {
+		t.Fatalf("Enrich() of another issuer's identity = (%+v, %v), want it unchanged", got, err)
+	}
+
+	for _, auth := range []string{"other subject", "malformed", "invalid"} {
+		if _, err := e.Enrich(ga4gh.NewAuthorizationContext(ctx, auth), alice); err == nil {
+			t.Errorf("Enrich() with %q succeeded, want error", auth)
+		}
+	}
+	if _, err := e.Enrich(ctx, alice); err == nil {
+		t.Error("Enrich() without an authorization succeeded, want error")
+	}
+}
+
+func TestEnrichCache(t *testing.T) {
+	srv, calls := newServer(map[string]string{"alice": `{"sub": "alice"}`})
+	defer srv.Close()
+	e, err := NewEnricher(context.Background(), Config{Issuer: srv.URL, Endpoint: srv.URL + "/userinfo", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+
+	clock := now
+	ctx := ga4gh.NewAuthorizationContext(ga4gh.NewClockContext(context.Background(), func() time.Time { return clock }), "alice")
+	alice := &ga4gh.Identity{Issuer: srv.URL, Subject: "alice"}
+	enrich := func(wantCalls int) {
+		t.Helper()
+		if _, err := e.Enrich(ctx, alice); err != nil {
+			t.Fatalf("Enrich() = %v", err)
+		}
+		if *calls != wantCalls {
+			t.Fatalf("after Enrich() the endpoint was called %d times, want %d", *calls, wantCalls)
+		}
+	}
+
+	enrich(1)
+	clock = clock.Add(59 * time.Second)
+	enrich(1)
+	clock = clock.Add(time.Second)
+	enrich(2)
+}
+
+func TestEnrichTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+	e, err := NewEnricher(context.Background(), Config{Issuer: srv.URL, Endpoint: srv.URL, Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+	ctx := ga4gh.NewAuthorizationContext(context.Background(), "alice")
+	if _, err := e.Enrich(ctx, &ga4gh.Identity{Issuer: srv.URL, Subject: "alice"}); err == nil {
+		t.Fatal("Enrich() of a blocked endpoint succeeded, want error")
+	}
+}
+
+func TestCustomDecoder(t *testing.T) {
+	srv, _ := newServer(map[string]string{"alice": `{"sub": "alice", "role": "researcher"}`})
+	defer srv.Close()
+	var decoded []byte
+	decoder := func(ctx context.Context, claims []byte) (*ga4gh.Identity, error) {
+		decoded = claims
+		return &ga4gh.Identity{Subject: "alice", Role: []ga4gh.StringValue{{Value: "researcher"}}}, nil
+	}
+	e, err := NewEnricher(context.Background(), Config{Issuer: srv.URL, Endpoint: srv.URL + "/userinfo", Decode: decoder})
+	if err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+	ctx := ga4gh.NewAuthorizationContext(context.Background(), "alice")
+	enriched, err := e.Enrich(ctx, &ga4gh.Identity{Issuer: srv.URL, Subject: "alice"})
+	if err != nil {
+		t.Fatalf("Enrich() = %v", err)
+	}
+	if string(decoded) != `{"sub": "alice", "role": "researcher"}` {
+		t.Fatalf("decoder called with %q", decoded)
+	}
+	if want := []ga4gh.StringValue{{Value: "researcher"}}; !reflect.DeepEqual(enriched.Role, want) {
+		t.Fatalf("Enrich() role = %+v, want = %+v", enriched.Role, want)
+	}
+
+	failing := func(ctx context.Context, claims []byte) (*ga4gh.Identity, error) {
+		return nil, errors.New("unsupported")
+	}
+	if e, err = NewEnricher(context.Background(), Config{Issuer: srv.URL, Endpoint: srv.URL + "/userinfo", Decode: failing}); err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+	if _, err := e.Enrich(ctx, &ga4gh.Identity{Issuer: srv.URL, Subject: "alice"}); err == nil {
+		t.Fatal("Enrich() with a failing decoder succeeded, want error")
+	}
+}
+
+func TestElixirDecoder(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	var srv *httptest.Server
+	visa := func(key *rsa.PrivateKey, value string) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+		if err != nil {
+			t.Fatalf("Error creating signer: %v", err)
+		}
+		token, err := jwt.Signed(signer).Claims(jwt.Claims{Issuer: srv.URL, Subject: "alice"}).Claims(map[string]interface{}{
+			"ga4gh_visa_v1": map[string]interface{}{"type": "ControlledAccessGrants", "value": value, "source": "dac"},
+		}).CompactSerialize()
+		if err != nil {
+			t.Fatalf("Error signing visa: %v", err)
+		}
+		return token
+	}
+
+	mux := http.NewServeMux()
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":            srv.URL,
+			"userinfo_endpoint": srv.URL + "/userinfo",
+			"jwks_uri":          srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, Algorithm: "RS256", Use: "sig"}}})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"sub": "alice",
+			"bona_fide_status": "https://doi.org/10.1038/s41431-018-0219-y",
+			"eduperson_scoped_affiliation": ["faculty@uni.example.org"],
+			"ga4gh_passport_v1": [%q, %q],
+			"ga4gh.Visas": [{"type": "ControlledAccessGrants", "value": "phs000712", "source": "forged"}]
+		}`, visa(key, "phs000710"), visa(otherKey, "phs000711"))
+	})
+
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	d, err := elixir.NewDecoder(ctx, srv.URL)
+	if err != nil {
+		t.Fatalf("NewDecoder() = %v", err)
+	}
+	e, err := NewEnricher(ctx, Config{Issuer: srv.URL, Decode: d.Decode})
+	if err != nil {
+		t.Fatalf("NewEnricher() = %v", err)
+	}
+	enriched, err := e.Enrich(ga4gh.NewAuthorizationContext(ctx, "alice"), &ga4gh.Identity{Issuer: srv.URL, Subject: "alice"})
+	if err != nil {
+		t.Fatalf("Enrich() = %v", err)
+	}
+	want := &ga4gh.Identity{
+		Issuer:                          srv.URL,
+		Subject:                         "alice",
+		AcademicInstitutionAffiliations: []ga4gh.StringValue{{Value: "faculty@uni.example.org", Source: srv.URL, Asserted: now.Unix()}},
+		BonaFide:                        []ga4gh.BoolValue{{Value: true, Source: srv.URL, Asserted: now.Unix()}},
+		Visas:                           []ga4gh.Visa{{Type: "ControlledAccessGrants", Value: "phs000710", Source: "dac"}},
+	}
+	if !reflect.DeepEqual(enriched, want) {
+		t.Fatalf("Enrich() = %+v, want = %+v", enriched, want)
+	}
+}
@@ -21,3 +21,18 @@ import "context"
 type Validator interface {
 	Validate(ctx context.Context, identity *Identity) (bool, error)
 }
+
+// Authorizer is used to determine whether a given Identity may access a
+// particular resource, such as a dataset.
+type Authorizer interface {
+	Authorize(ctx context.Context, identity *Identity, resource string) (bool, error)
+}
+
+// Enricher is used to add information that is not contained in the token,
+// such as group memberships, to a parsed Identity.  Implementations must not
+// modify the identity they are passed; they return a modified copy instead.
+// Evaluator makes the token available to them through
+// AuthorizationFromContext.
+type Enricher interface {
+	Enrich(ctx context.Context, identity *Identity) (*Identity, error)
+}
@@ -61,3 +61,75 @@ func (and And) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, er
 	}
 	return true, nil
 }
+
+// Not is a ga4gh.Validator that inverts the result of the wrapped validator.
+type Not struct {
+	Validator ga4gh.Validator
+}
+
+// Validate returns true iff the wrapped validator returns false.  If the
+// wrapped validator returns an error then an error is returned.
+func (not *Not) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	ok, err := not.Validator.Validate(ctx, identity)
+	if err != nil {
+		return false, fmt.Errorf("nested validator: %v", err)
+	}
+	return !ok, nil
+}
+
+// AtLeast is a ga4gh.Validator that succeeds if at least N of the wrapped
+// validators return true.  Evaluation short-circuits and does not necessarily
+// evaluate all wrapped validators.
+type AtLeast struct {
+	N          int
+	Validators []ga4gh.Validator
+}
+
+// Validate returns true iff at least N of the wrapped validators return true.
+// If any of the invoked validators return an error then an error is returned.
+func (al *AtLeast) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	count := 0
+	for i, v := range al.Validators {
+		if count >= al.N {
+			return true, nil
+		}
+		if count+len(al.Validators)-i < al.N {
+			return false, nil
+		}
+		ok, err := v.Validate(ctx, identity)
+		if err != nil {
+			return false, fmt.Errorf("nested validator at index %d: %v", i, err)
+		}
+		if ok {
+			count++
+		}
+	}
+	return count >= al.N, nil
+}
+
+// Exactly is a ga4gh.Validator that succeeds if exactly N of the wrapped
+// validators return true.  Evaluation short-circuits once the count can no
+// longer be N, and so does not necessarily evaluate all wrapped validators.
+type Exactly struct {
+	N          int
+	Validators []ga4gh.Validator
+}
+
+// Validate returns true iff exactly N of the wrapped validators return true.
+// If any of the invoked validators return an error then an error is returned.
+func (ex *Exactly) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	count := 0
+	for i, v := range ex.Validators {
+		if count > ex.N || count+len(ex.Validators)-i < ex.N {
+			return false, nil
+		}
+		ok, err := v.Validate(ctx, identity)
+		if err != nil {
+			return false, fmt.Errorf("nested validator at index %d: %v", i, err)
+		}
+		if ok {
+			count++
+		}
+	}
+	return count == ex.N, nil
+}
@@ -75,6 +75,66 @@ func TestBoolean(t *testing.T) {
 			in:   Or{&Constant{OK: false}, &Constant{Err: errors.New("failure")}},
 			err:  true,
 		},
+		{
+			name: "not true",
+			in:   &Not{&Constant{OK: true}},
+			ok:   false,
+		},
+		{
+			name: "not false",
+			in:   &Not{&Constant{OK: false}},
+			ok:   true,
+		},
+		{
+			name: "not error",
+			in:   &Not{&Constant{Err: errors.New("failure")}},
+			err:  true,
+		},
+		{
+			name: "at least two of three",
+			in:   &AtLeast{N: 2, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{OK: false}, &Constant{OK: true}}},
+			ok:   true,
+		},
+		{
+			name: "at least two of three, one true",
+			in:   &AtLeast{N: 2, Validators: []ga4gh.Validator{&Constant{OK: false}, &Constant{OK: false}, &Constant{OK: true}}},
+			ok:   false,
+		},
+		{
+			name: "at least short-circuits before error",
+			in:   &AtLeast{N: 1, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{Err: errors.New("failure")}}},
+			ok:   true,
+		},
+		{
+			name: "at least error",
+			in:   &AtLeast{N: 2, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{Err: errors.New("failure")}}},
+			err:  true,
+		},
+		{
+			name: "at least zero",
+			in:   &AtLeast{N: 0},
+			ok:   true,
+		},
+		{
+			name: "exactly one of two",
+			in:   &Exactly{N: 1, Validators: []ga4gh.Validator{&Constant{OK: false}, &Constant{OK: true}}},
+			ok:   true,
+		},
+		{
+			name: "exactly one of two, both true",
+			in:   &Exactly{N: 1, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{OK: true}}},
+			ok:   false,
+		},
+		{
+			name: "exactly short-circuits before error",
+			in:   &Exactly{N: 0, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{Err: errors.New("failure")}}},
+			ok:   false,
+		},
+		{
+			name: "exactly error",
+			in:   &Exactly{N: 1, Validators: []ga4gh.Validator{&Constant{OK: true}, &Constant{Err: errors.New("failure")}}},
+			err:  true,
+		},
 	}
 	ctx := context.Background()
 	for _, test := range tests {
@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Datasets is a ga4gh.Authorizer that maps dataset IDs to the validator that
+// identities must pass to access the dataset.  A key ending in "*" applies to
+// every dataset with the preceding prefix, so "*" alone sets the policy for
+// datasets without a more specific entry.  For example, the Datasets:
+//
+//	Datasets{
+//		"phs000710":                 Simple{"Role": "researcher"},
+//		"https://doi.org/10.5281/*": Sourced{"BonaFide": {Value: true, Sources: elixir}},
+//	}
+//
+// would allow researchers to access phs000710 and bona fide researchers, as
+// asserted by the sources in elixir, to access any dataset with a DOI in the
+// 10.5281 prefix.  Access to datasets without a policy is denied.
+type Datasets map[string]ga4gh.Validator
+
+// Authorize implements the ga4gh.Authorizer interface using the policy with
+// the exact dataset ID if there is one, and otherwise the policy with the
+// longest matching prefix.
+func (d Datasets) Authorize(ctx context.Context, identity *ga4gh.Identity, dataset string) (bool, error) {
+	v, ok := d.policy(dataset)
+	if !ok {
+		return false, nil
+	}
+	ok, err := v.Validate(ctx, identity)
+	if err != nil {
+		return false, fmt.Errorf("dataset %q: %v", dataset, err)
+	}
+	return ok, nil
+}
+
+func (d Datasets) policy(dataset string) (ga4gh.Validator, bool) {
+	if v, ok := d[dataset]; ok && !strings.HasSuffix(dataset, "*") {
+		return v, true
+	}
+	var best string
+	var found bool
+	for key := range d {
+		prefix := strings.TrimSuffix(key, "*")
+		if prefix == key || !strings.HasPrefix(dataset, prefix) {
+			continue
+		}
+		if !found || len(prefix) > len(best) {
+			best, found = prefix, true
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	return d[best+"*"], true
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestDatasets(t *testing.T) {
+	id := &ga4gh.Identity{
+		Role: []ga4gh.StringValue{{Value: "researcher"}},
+	}
+	datasets := Datasets{
+		"phs000710":                          Simple{"Role": "researcher"},
+		"phs000711":                          Simple{"Role": "curator"},
+		"https://doi.org/10.5281/*":          &Constant{OK: true},
+		"https://doi.org/10.5281/zenodo.9*":  &Constant{OK: false},
+		"https://doi.org/10.5281/zenodo.90*": Simple{"Role": "researcher"},
+		"broken":                             Simple{"Rol": "researcher"},
+	}
+	tests := []struct {
+		dataset string
+		ok      bool
+		err     bool
+	}{
+		{dataset: "phs000710", ok: true},
+		{dataset: "phs000711", ok: false},
+		{dataset: "phs000712", ok: false},
+		{dataset: "https://doi.org/10.5281/zenodo.1", ok: true},
+		{dataset: "https://doi.org/10.5281/zenodo.91", ok: false},
+		{dataset: "https://doi.org/10.5281/zenodo.901", ok: true},
+		{dataset: "broken", err: true},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.dataset, func(t *testing.T) {
+			ok, err := datasets.Authorize(ctx, id, test.dataset)
+			if test.err != (err != nil) {
+				t.Fatalf("Unexpected error during authorization: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected authorization result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
+
+func TestDatasetsDefault(t *testing.T) {
+	datasets := Datasets{
+		"phs000710": &Constant{OK: false},
+		"*":         &Constant{OK: true},
+	}
+	ctx := context.Background()
+	if ok, err := datasets.Authorize(ctx, &ga4gh.Identity{}, "phs000710"); ok || err != nil {
+		t.Fatalf("Authorize(phs000710) = (%v, %v), want = (false, nil)", ok, err)
+	}
+	if ok, err := datasets.Authorize(ctx, &ga4gh.Identity{}, "other"); !ok || err != nil {
+		t.Fatalf("Authorize(other) = (%v, %v), want = (true, nil)", ok, err)
+	}
+}
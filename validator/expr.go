@@ -0,0 +1,677 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Expression is a ga4gh.Validator that evaluates a boolean expression over the
+// incoming identity.  Expressions are type checked when they are compiled, so
+// a compiled Expression never fails at evaluation time.
+//
+// The language supports string, number and boolean literals, lists such as
+// ["a", "b"], the operators !, &&, ||, ==, != and in, and parentheses.  The
+// fields of the identity are available as identity.<Field>, where <Field> is
+// the name of a ga4gh.Identity field; claim fields evaluate to the list of
+// their values, and identity.iss and identity.sub are shorthands for
+// identity.Issuer and identity.Subject.  The values of the identity's active
+// visas of each type are available as visa.<Type>, such as
+// visa.ControlledAccessGrants; see ga4gh.ActiveVisas.  The following functions
+// are provided:
+//
+//	has(list, value)        value is an element of list, like value in list
+//	size(list)              the number of elements in list
+//	startsWith(s, prefix)   s, or any element of a list s, starts with prefix
+//	endsWith(s, suffix)     s, or any element of a list s, ends with suffix
+//	matches(s, "regexp")    s, or any element of a list s, is matched in its
+//	                        entirety by the regular expression, which must be
+//	                        a string literal
+//
+// For example:
+//
+//	has(visa.ControlledAccessGrants, "phs000710") && identity.iss in trusted
+type Expression struct {
+	source string
+	eval   func(*ga4gh.Identity) interface{}
+	// visas is true if the expression refers to visas, which must be filtered
+	// with ga4gh.ActiveVisas before it is evaluated.
+	visas bool
+}
+
+// CompileExpression parses and type checks source.  Variables makes named
+// values available to the expression; their values must be strings, bools,
+// float64s, or slices of one of those types.
+func CompileExpression(source string, variables map[string]interface{}) (*Expression, error) {
+	vars := make(map[string]node)
+	for name, value := range variables {
+		n, err := constantNode(value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %v", name, err)
+		}
+		vars[name] = n
+	}
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, vars: vars}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, t.errorf("unexpected %s", t)
+	}
+	if n.typ != typeBool {
+		return nil, fmt.Errorf("expression has type %v, want bool", n.typ)
+	}
+	return &Expression{source: source, eval: n.eval, visas: p.visas}, nil
+}
+
+// Validate implements the ga4gh.Validator interface.
+func (e *Expression) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	if e.visas && len(identity.Visas) > 0 {
+		copied := *identity
+		copied.Visas = ga4gh.ActiveVisas(ctx, identity.Visas)
+		identity = &copied
+	}
+	return e.eval(identity).(bool), nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// exprType is the static type of an expression.  Lists are represented at
+// evaluation time as []interface{}.
+type exprType int
+
+const (
+	typeBool exprType = iota
+	typeString
+	typeNumber
+	typeBoolList
+	typeStringList
+	typeNumberList
+)
+
+func (t exprType) String() string {
+	return [...]string{"bool", "string", "number", "list<bool>", "list<string>", "list<number>"}[t]
+}
+
+func (t exprType) isList() bool {
+	return t >= typeBoolList
+}
+
+// elem returns the type of the elements of a list type.
+func (t exprType) elem() exprType {
+	return t - typeBoolList
+}
+
+// list returns the type of a list of t.
+func (t exprType) list() exprType {
+	return t + typeBoolList
+}
+
+// node is a type checked expression compiled into a function.
+type node struct {
+	typ  exprType
+	eval func(*ga4gh.Identity) interface{}
+}
+
+func constant(typ exprType, value interface{}) node {
+	return node{typ: typ, eval: func(*ga4gh.Identity) interface{} { return value }}
+}
+
+// constantNode converts a Go value into a constant node.
+func constantNode(value interface{}) (node, error) {
+	switch v := value.(type) {
+	case string:
+		return constant(typeString, v), nil
+	case bool:
+		return constant(typeBool, v), nil
+	case float64:
+		return constant(typeNumber, v), nil
+	case []string:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = v[i]
+		}
+		return constant(typeStringList, list), nil
+	case []bool:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = v[i]
+		}
+		return constant(typeBoolList, list), nil
+	case []float64:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = v[i]
+		}
+		return constant(typeNumberList, list), nil
+	}
+	return node{}, fmt.Errorf("unsupported type %T", value)
+}
+
+// identityAliases maps the JWT claim names of ga4gh.Identity fields to the
+// field names.
+var identityAliases = map[string]string{
+	"iss": "Issuer",
+	"sub": "Subject",
+}
+
+// fieldNode returns a node that evaluates to the named field of the identity.
+func fieldNode(name string) (node, bool) {
+	if alias, ok := identityAliases[name]; ok {
+		name = alias
+	}
+	field, ok := reflect.TypeOf(ga4gh.Identity{}).FieldByName(name)
+	if !ok {
+		return node{}, false
+	}
+	var typ exprType
+	switch kind, _ := FieldType(name); kind.Kind() {
+	case reflect.String:
+		typ = typeString
+	case reflect.Bool:
+		typ = typeBool
+	default:
+		return node{}, false
+	}
+	if !valueType[field.Type] {
+		return node{typ: typ, eval: func(identity *ga4gh.Identity) interface{} {
+			return reflect.ValueOf(identity).Elem().FieldByIndex(field.Index).Interface()
+		}}, true
+	}
+	return node{typ: typ.list(), eval: func(identity *ga4gh.Identity) interface{} {
+		values := reflect.ValueOf(identity).Elem().FieldByIndex(field.Index)
+		list := make([]interface{}, values.Len())
+		for i := range list {
+			list[i] = values.Index(i).FieldByName("Value").Interface()
+		}
+		return list
+	}}, true
+}
+
+// visaNode returns a node that evaluates to the values of the identity's
+// visas of the given type.
+func visaNode(typ string) node {
+	return node{typ: typeStringList, eval: func(identity *ga4gh.Identity) interface{} {
+		var list []interface{}
+		for _, v := range identity.Visas {
+			if v.Type == typ {
+				list = append(list, v.Value)
+			}
+		}
+		return list
+	}}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// errorf returns an error that refers to the position of the token.
+func (t token) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("offset %d: %s", t.pos, fmt.Sprintf(format, args...))
+}
+
+var punctuation = []string{"&&", "||", "==", "!=", "!", "(", ")", "[", "]", ",", "."}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(source); {
+		r, size := utf8.DecodeRuneInString(source[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+
+		case r == '_' || unicode.IsLetter(r):
+			end := scan(source, pos, func(r rune) bool {
+				return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+			})
+			tokens = append(tokens, token{kind: tokIdent, text: source[pos:end], pos: pos})
+			pos = end
+
+		case unicode.IsDigit(r):
+			end := scan(source, pos, func(r rune) bool {
+				return r == '.' || unicode.IsDigit(r)
+			})
+			tokens = append(tokens, token{kind: tokNumber, text: source[pos:end], pos: pos})
+			pos = end
+
+		case r == '"':
+			end := pos + 1
+			for end < len(source) && source[end] != '"' {
+				if source[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(source) {
+				return nil, fmt.Errorf("offset %d: unterminated string", pos)
+			}
+			text, err := strconv.Unquote(source[pos : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("offset %d: invalid string: %v", pos, err)
+			}
+			tokens = append(tokens, token{kind: tokString, text: text, pos: pos})
+			pos = end + 1
+
+		default:
+			var matched bool
+			for _, p := range punctuation {
+				if strings.HasPrefix(source[pos:], p) {
+					tokens = append(tokens, token{kind: tokPunct, text: p, pos: pos})
+					pos += len(p)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("offset %d: unexpected character %q", pos, r)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(source)}), nil
+}
+
+// scan returns the offset of the first rune in source at or after pos that
+// is not accepted by accept.
+func scan(source string, pos int, accept func(rune) bool) int {
+	for pos < len(source) {
+		r, size := utf8.DecodeRuneInString(source[pos:])
+		if !accept(r) {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
+
+type parser struct {
+	tokens []token
+	vars   map[string]node
+	// visas is set when the expression refers to visas.
+	visas bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[0]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[0]
+	if t.kind != tokEOF {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+// accept consumes the next token if it is the punctuation or keyword text.
+func (p *parser) accept(text string) bool {
+	if t := p.peek(); (t.kind == tokPunct || t.kind == tokIdent) && t.text == text {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.peek(); !p.accept(text) {
+		return t.errorf("got %s, want %q", t, text)
+	}
+	return nil
+}
+
+// expr parses a disjunction, the lowest precedence expression.
+func (p *parser) expr() (node, error) {
+	left, err := p.conjunction()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		op := p.peek()
+		if !p.accept("||") {
+			return left, nil
+		}
+		right, err := p.conjunction()
+		if err != nil {
+			return node{}, err
+		}
+		if left.typ != typeBool || right.typ != typeBool {
+			return node{}, op.errorf("operands of || must be bool, got %v and %v", left.typ, right.typ)
+		}
+		l, r := left.eval, right.eval
+		left = node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+			return l(identity).(bool) || r(identity).(bool)
+		}}
+	}
+}
+
+func (p *parser) conjunction() (node, error) {
+	left, err := p.comparison()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		op := p.peek()
+		if !p.accept("&&") {
+			return left, nil
+		}
+		right, err := p.comparison()
+		if err != nil {
+			return node{}, err
+		}
+		if left.typ != typeBool || right.typ != typeBool {
+			return node{}, op.errorf("operands of && must be bool, got %v and %v", left.typ, right.typ)
+		}
+		l, r := left.eval, right.eval
+		left = node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+			return l(identity).(bool) && r(identity).(bool)
+		}}
+	}
+}
+
+func (p *parser) comparison() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return node{}, err
+	}
+	op := p.peek()
+	switch {
+	case p.accept("=="), p.accept("!="):
+		right, err := p.unary()
+		if err != nil {
+			return node{}, err
+		}
+		if left.typ != right.typ || left.typ.isList() {
+			return node{}, op.errorf("cannot compare %v with %v", left.typ, right.typ)
+		}
+		l, r, equal := left.eval, right.eval, op.text == "=="
+		return node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+			return (l(identity) == r(identity)) == equal
+		}}, nil
+
+	case p.accept("in"):
+		right, err := p.unary()
+		if err != nil {
+			return node{}, err
+		}
+		return contains(op, right, left)
+	}
+	return left, nil
+}
+
+// contains returns a node that reports whether value is an element of list.
+func contains(at token, list, value node) (node, error) {
+	if !list.typ.isList() || list.typ.elem() != value.typ {
+		return node{}, at.errorf("cannot look for %v in %v", value.typ, list.typ)
+	}
+	l, v := list.eval, value.eval
+	return node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+		want := v(identity)
+		for _, elem := range l(identity).([]interface{}) {
+			if elem == want {
+				return true
+			}
+		}
+		return false
+	}}, nil
+}
+
+func (p *parser) unary() (node, error) {
+	op := p.peek()
+	if !p.accept("!") {
+		return p.primary()
+	}
+	operand, err := p.unary()
+	if err != nil {
+		return node{}, err
+	}
+	if operand.typ != typeBool {
+		return node{}, op.errorf("operand of ! must be bool, got %v", operand.typ)
+	}
+	o := operand.eval
+	return node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+		return !o(identity).(bool)
+	}}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return constant(typeString, t.text), nil
+
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return node{}, t.errorf("invalid number %s", t)
+		}
+		return constant(typeNumber, f), nil
+
+	case tokIdent:
+		switch {
+		case t.text == "true" || t.text == "false":
+			return constant(typeBool, t.text == "true"), nil
+		case t.text == "identity":
+			if err := p.expect("."); err != nil {
+				return node{}, err
+			}
+			name := p.next()
+			n, ok := fieldNode(name.text)
+			if name.kind != tokIdent || !ok {
+				return node{}, name.errorf("no field named %s on ga4gh.Identity", name)
+			}
+			return n, nil
+		case t.text == "visa":
+			if err := p.expect("."); err != nil {
+				return node{}, err
+			}
+			typ := p.next()
+			if typ.kind != tokIdent {
+				return node{}, typ.errorf("got %s, want a visa type", typ)
+			}
+			p.visas = true
+			return visaNode(typ.text), nil
+		case p.accept("("):
+			return p.call(t)
+		}
+		n, ok := p.vars[t.text]
+		if !ok {
+			return node{}, t.errorf("undefined variable %s", t)
+		}
+		return n, nil
+
+	case tokPunct:
+		switch t.text {
+		case "(":
+			n, err := p.expr()
+			if err != nil {
+				return node{}, err
+			}
+			return n, p.expect(")")
+		case "[":
+			return p.list(t)
+		}
+	}
+	return node{}, t.errorf("unexpected %s", t)
+}
+
+// list parses the elements of a list literal after the opening bracket.
+func (p *parser) list(open token) (node, error) {
+	var elems []node
+	for !p.accept("]") {
+		if len(elems) > 0 {
+			if err := p.expect(","); err != nil {
+				return node{}, err
+			}
+		}
+		n, err := p.expr()
+		if err != nil {
+			return node{}, err
+		}
+		if n.typ.isList() {
+			return node{}, open.errorf("lists cannot contain %v", n.typ)
+		}
+		if len(elems) > 0 && n.typ != elems[0].typ {
+			return node{}, open.errorf("list mixes %v and %v", elems[0].typ, n.typ)
+		}
+		elems = append(elems, n)
+	}
+	if len(elems) == 0 {
+		return node{}, open.errorf("empty lists are not supported")
+	}
+	return node{typ: elems[0].typ.list(), eval: func(identity *ga4gh.Identity) interface{} {
+		list := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			list[i] = elem.eval(identity)
+		}
+		return list
+	}}, nil
+}
+
+// functionArity maps the names of the functions in the language to the number
+// of arguments they take.
+var functionArity = map[string]int{
+	"has":        2,
+	"size":       1,
+	"startsWith": 2,
+	"endsWith":   2,
+	"matches":    2,
+}
+
+// call parses the arguments of a call to the function named by fn, after the
+// opening parenthesis.
+func (p *parser) call(fn token) (node, error) {
+	var args []node
+	var literals []token
+	for !p.accept(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return node{}, err
+			}
+		}
+		literals = append(literals, p.peek())
+		n, err := p.expr()
+		if err != nil {
+			return node{}, err
+		}
+		args = append(args, n)
+	}
+
+	n, ok := functionArity[fn.text]
+	if !ok {
+		return node{}, fn.errorf("undefined function %s", fn)
+	}
+	if len(args) != n {
+		return node{}, fn.errorf("%s takes %d arguments, got %d", fn.text, n, len(args))
+	}
+
+	switch fn.text {
+	case "has":
+		return contains(fn, args[0], args[1])
+
+	case "size":
+		if !args[0].typ.isList() {
+			return node{}, fn.errorf("size of %v", args[0].typ)
+		}
+		l := args[0].eval
+		return node{typ: typeNumber, eval: func(identity *ga4gh.Identity) interface{} {
+			return float64(len(l(identity).([]interface{})))
+		}}, nil
+
+	case "startsWith", "endsWith":
+		if args[1].typ != typeString {
+			return node{}, fn.errorf("second argument of %s must be string, got %v", fn.text, args[1].typ)
+		}
+		affix := args[1].eval
+		test := strings.HasPrefix
+		if fn.text == "endsWith" {
+			test = strings.HasSuffix
+		}
+		return anyString(fn, args[0], func(identity *ga4gh.Identity, s string) bool {
+			return test(s, affix(identity).(string))
+		})
+
+	case "matches":
+		// A string typed argument that starts with a string token can only be
+		// a literal, since the language has no string operators.
+		if literals[1].kind != tokString || args[1].typ != typeString {
+			return node{}, literals[1].errorf("second argument of matches must be a string literal")
+		}
+		re, err := NewRegexp(literals[1].text)
+		if err != nil {
+			return node{}, literals[1].errorf("compiling regexp: %v", err)
+		}
+		return anyString(fn, args[0], func(_ *ga4gh.Identity, s string) bool {
+			return re.Match(s)
+		})
+	}
+	return node{}, fn.errorf("undefined function %s", fn)
+}
+
+// anyString returns a node that reports whether test accepts arg, or if arg is
+// a list of strings, any of its elements.
+func anyString(fn token, arg node, test func(*ga4gh.Identity, string) bool) (node, error) {
+	a := arg.eval
+	switch arg.typ {
+	case typeString:
+		return node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+			return test(identity, a(identity).(string))
+		}}, nil
+	case typeStringList:
+		return node{typ: typeBool, eval: func(identity *ga4gh.Identity) interface{} {
+			for _, elem := range a(identity).([]interface{}) {
+				if test(identity, elem.(string)) {
+					return true
+				}
+			}
+			return false
+		}}, nil
+	}
+	return node{}, fn.errorf("first argument of %s must be string or list<string>, got %v", fn.text, arg.typ)
+}
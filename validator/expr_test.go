@@ -0,0 +1,147 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestExpression(t *testing.T) {
+	id := &ga4gh.Identity{
+		Subject: "alice",
+		Issuer:  "https://login.elixir-czech.org/oidc/",
+		Role:    []ga4gh.StringValue{{Value: "researcher"}, {Value: "curator"}},
+		AcademicInstitutionAffiliations: []ga4gh.StringValue{
+			{Value: "faculty@uni.example.org"},
+		},
+		BonaFide: []ga4gh.BoolValue{{Value: true}},
+		Visas: []ga4gh.Visa{
+			{Type: "ControlledAccessGrants", Value: "phs000710"},
+			{Type: "ControlledAccessGrants", Value: "phs000711", Expires: 1},
+			{Type: "ControlledAccessGrants", Value: "phs000712", Conditions: [][]ga4gh.Condition{{{Type: "ResearcherStatus", Value: "const:yes"}}}},
+			{Type: "AffiliationAndRole", Value: "faculty@uni.example.org"},
+		},
+	}
+	vars := map[string]interface{}{
+		"trusted": []string{"https://login.elixir-czech.org/oidc/", "https://idp.example.com"},
+		"owner":   "alice",
+	}
+	tests := []struct {
+		source string
+		ok     bool
+	}{
+		{source: `true`, ok: true},
+		{source: `!true`, ok: false},
+		{source: `has(identity.Role, "researcher")`, ok: true},
+		{source: `"robot" in identity.Role`, ok: false},
+		{source: `identity.iss in trusted`, ok: true},
+		{source: `identity.sub == owner && identity.Subject != "bob"`, ok: true},
+		{source: `has(identity.Role, "robot") || has(identity.BonaFide, true)`, ok: true},
+		{source: `!(has(identity.Role, "robot") || false)`, ok: true},
+		{source: `size(identity.Role) == 2`, ok: true},
+		{source: `size(identity.OriginOrganization) == 0`, ok: true},
+		{source: `startsWith(identity.iss, "https://login.elixir")`, ok: true},
+		{source: `endsWith(identity.AcademicInstitutionAffiliations, "@uni.example.org")`, ok: true},
+		{source: `matches(identity.AcademicInstitutionAffiliations, "(faculty|staff)@.*")`, ok: true},
+		{source: `matches(identity.Role, "cura")`, ok: false},
+		{source: `identity.sub in ["bob", "carol"]`, ok: false},
+		{source: `1.5 in [1, 1.5]`, ok: true},
+		{source: `has(visa.ControlledAccessGrants, "phs000710") && identity.iss in trusted`, ok: true},
+		{source: `"phs000711" in visa.ControlledAccessGrants`, ok: false},
+		{source: `"phs000712" in visa.ControlledAccessGrants`, ok: false},
+		{source: `endsWith(visa.AffiliationAndRole, "@uni.example.org")`, ok: true},
+		{source: `size(visa.LinkedIdentities) == 0`, ok: true},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			e, err := CompileExpression(test.source, vars)
+			if err != nil {
+				t.Fatalf("CompileExpression() = %v", err)
+			}
+			ok, err := e.Validate(ctx, id)
+			if err != nil {
+				t.Fatalf("Unexpected error during validation: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected validation result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
+
+func TestCompileExpressionErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: ``, want: "offset 0: unexpected end of expression"},
+		{source: `identity.Role`, want: "expression has type list<string>, want bool"},
+		{source: `identity.Rol == "x"`, want: `offset 9: no field named "Rol" on ga4gh.Identity`},
+		{source: `identity.iss == 1`, want: "offset 13: cannot compare string with number"},
+		{source: `identity.Role == identity.Role`, want: "offset 14: cannot compare list<string> with list<string>"},
+		{source: `true && "x"`, want: "offset 5: operands of && must be bool, got bool and string"},
+		{source: `true in identity.Role`, want: "offset 5: cannot look for bool in list<string>"},
+		{source: `!identity.sub`, want: "offset 0: operand of ! must be bool, got string"},
+		{source: `unknown`, want: `offset 0: undefined variable "unknown"`},
+		{source: `nope(1)`, want: `offset 0: undefined function "nope"`},
+		{source: `has(identity.Role)`, want: "offset 0: has takes 2 arguments, got 1"},
+		{source: `matches(identity.sub, owner)`, want: "offset 22: second argument of matches must be a string literal"},
+		{source: `matches(identity.sub, "(")`, want: "offset 22: compiling regexp: error parsing regexp: missing closing ): `(`"},
+		{source: `startsWith(identity.BonaFide, "t")`, want: "offset 0: first argument of startsWith must be string or list<string>, got list<bool>"},
+		{source: `["a", 1] == "a"`, want: "offset 0: list mixes string and number"},
+		{source: `true true`, want: `offset 5: unexpected "true"`},
+		{source: `"open`, want: "offset 0: unterminated string"},
+		{source: `identity.sub = "a"`, want: `offset 13: unexpected character '='`},
+		{source: `identity.Visas == "a"`, want: `offset 9: no field named "Visas" on ga4gh.Identity`},
+		{source: `visa == "a"`, want: `offset 5: got "==", want "."`},
+		{source: `has(visa.1, "a")`, want: `offset 9: got "1", want a visa type`},
+		{source: `identity.sub == größe`, want: `offset 16: undefined variable "größe"`},
+		{source: `identity.sub == «a»`, want: `offset 16: unexpected character '«'`},
+	}
+	vars := map[string]interface{}{"owner": "alice"}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			_, err := CompileExpression(test.source, vars)
+			if err == nil {
+				t.Fatal("CompileExpression() succeeded, want error")
+			}
+			if got := err.Error(); got != test.want {
+				t.Fatalf("CompileExpression() = %q, want = %q", got, test.want)
+			}
+		})
+	}
+}
+
+func ExampleExpression() {
+	id := &ga4gh.Identity{
+		Issuer: "https://login.elixir-czech.org/oidc/",
+		Visas:  []ga4gh.Visa{{Type: "ControlledAccessGrants", Value: "phs000710"}},
+	}
+	e, err := CompileExpression(`has(visa.ControlledAccessGrants, "phs000710") && identity.iss in trusted`, map[string]interface{}{
+		"trusted": []string{"https://login.elixir-czech.org/oidc/"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	if ok, err := e.Validate(context.Background(), id); ok && err == nil {
+		fmt.Println("validated!")
+	}
+	// Output: validated!
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Matcher decides whether a string claim value is acceptable.
+type Matcher interface {
+	Match(value string) bool
+}
+
+// Regexp is a Matcher that accepts values matched in their entirety by a
+// regular expression.
+type Regexp struct {
+	re *regexp.Regexp
+}
+
+// NewRegexp compiles expr, in the syntax accepted by the regexp package, into a
+// Regexp.
+func NewRegexp(expr string) (*Regexp, error) {
+	// Compile expr on its own first so that errors refer to the expression
+	// as written rather than the anchored form.
+	if _, err := regexp.Compile(expr); err != nil {
+		return nil, err
+	}
+	return &Regexp{re: regexp.MustCompile(`^(?:` + expr + `)$`)}, nil
+}
+
+// Match implements the Matcher interface.
+func (r *Regexp) Match(value string) bool {
+	return r.re.MatchString(value)
+}
+
+// NewGlob compiles pattern into a Matcher.  In the pattern "*" matches any
+// sequence of characters, including none, and "?" matches any single
+// character; all other characters match themselves.  For example,
+// "https://doi.org/10.5281/*" matches every DOI with the 10.5281 prefix.
+func NewGlob(pattern string) *Regexp {
+	var re strings.Builder
+	re.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+	return &Regexp{re: regexp.MustCompile(re.String())}
+}
+
+// Prefix is a Matcher that accepts values starting with the prefix.
+type Prefix string
+
+// Match implements the Matcher interface.
+func (p Prefix) Match(value string) bool {
+	return strings.HasPrefix(value, string(p))
+}
+
+// Set is a Matcher that accepts values that are members of the set.
+type Set map[string]bool
+
+// NewSet creates a Set containing values.
+func NewSet(values ...string) Set {
+	s := make(Set)
+	for _, v := range values {
+		s[v] = true
+	}
+	return s
+}
+
+// Match implements the Matcher interface.
+func (s Set) Match(value string) bool {
+	return s[value]
+}
+
+// Match is a ga4gh.Validator that, like Simple, checks the claims of the
+// incoming identity, but accepts any value allowed by a Matcher rather than a
+// single value.  For example, the Match validator:
+//
+//	Match{"Role": NewSet("researcher", "curator")}
+//
+// would validate all identities with at least one Role that is either
+// "researcher" or "curator".  Only string claims may be matched.
+type Match map[string]Matcher
+
+// Validate returns true iff, for each key in the Match, the corresponding field
+// in the input contains at least one value accepted by the Matcher.
+func (m Match) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	v := reflect.ValueOf(*identity)
+	for name, matcher := range m {
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return false, fmt.Errorf("no field named %q on ga4gh.Identity", name)
+		}
+		if typ, _ := FieldType(name); typ.Kind() != reflect.String {
+			return false, fmt.Errorf("field %q of ga4gh.Identity is not a string", name)
+		}
+		var matched bool
+		if valueType[field.Type()] {
+			for i := 0; i < field.Len(); i++ {
+				if matcher.Match(field.Index(i).FieldByName("Value").String()) {
+					matched = true
+					break
+				}
+			}
+		} else {
+			matched = matcher.Match(field.String())
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
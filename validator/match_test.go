@@ -0,0 +1,110 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestMatchers(t *testing.T) {
+	re, err := NewRegexp(`phs\d+`)
+	if err != nil {
+		t.Fatalf("NewRegexp() = %v", err)
+	}
+	tests := []struct {
+		name    string
+		matcher Matcher
+		value   string
+		want    bool
+	}{
+		{name: "regexp", matcher: re, value: "phs000710", want: true},
+		{name: "regexp is anchored", matcher: re, value: "xphs000710", want: false},
+		{name: "glob", matcher: NewGlob("https://doi.org/10.5281/*"), value: "https://doi.org/10.5281/zenodo.1", want: true},
+		{name: "glob quotes metacharacters", matcher: NewGlob("a.b?"), value: "axbc", want: false},
+		{name: "glob single character", matcher: NewGlob("a.b?"), value: "a.bc", want: true},
+		{name: "prefix", matcher: Prefix("https://doi.org/"), value: "https://doi.org/10.1", want: true},
+		{name: "prefix mismatch", matcher: Prefix("https://doi.org/"), value: "http://doi.org/10.1", want: false},
+		{name: "set", matcher: NewSet("a", "b"), value: "b", want: true},
+		{name: "set mismatch", matcher: NewSet("a", "b"), value: "c", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.matcher.Match(test.value); got != test.want {
+				t.Fatalf("Match(%q) = %v, want = %v", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	id := &ga4gh.Identity{
+		Issuer: "https://login.elixir-czech.org/oidc/",
+		Role:   []ga4gh.StringValue{{Value: "human"}, {Value: "curator"}},
+	}
+	tests := []struct {
+		name      string
+		validator Match
+		ok        bool
+		err       bool
+	}{
+		{
+			name:      "list field",
+			validator: Match{"Role": NewSet("researcher", "curator")},
+			ok:        true,
+		},
+		{
+			name:      "scalar field",
+			validator: Match{"Issuer": Prefix("https://login.elixir-czech.org/")},
+			ok:        true,
+		},
+		{
+			name:      "no match",
+			validator: Match{"Role": Prefix("robot")},
+			ok:        false,
+		},
+		{
+			name:      "non-string field",
+			validator: Match{"BonaFide": Prefix("t")},
+			err:       true,
+		},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := test.validator.Validate(ctx, id)
+			if test.err != (err != nil) {
+				t.Fatalf("Unexpected error during validation: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected validation result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
+
+func ExampleMatch() {
+	id := &ga4gh.Identity{
+		Role: []ga4gh.StringValue{{Value: "curator"}},
+	}
+	v := Match{"Role": NewSet("researcher", "curator")}
+	if ok, err := v.Validate(context.Background(), id); ok && err == nil {
+		fmt.Println("validated!")
+	}
+	// Output: validated!
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// ParallelOr is a ga4gh.Validator that, like Or, succeeds if any of the
+// wrapped validators returns true, but invokes all of them concurrently.  Once
+// the outcome is known the context passed to the validators that are still
+// running is cancelled.  The result, including any error, is the same as that
+// of an Or wrapping the same validators.
+type ParallelOr []ga4gh.Validator
+
+// Validate returns true iff one of the validators that this ParallelOr wraps
+// returns true.  If a validator returns an error before any validator with a
+// lower index returns true then an error is returned.
+func (or ParallelOr) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	i, r, err := runParallel(ctx, identity, or, func(r result) bool { return r.ok })
+	if err != nil {
+		return false, err
+	}
+	if i < 0 {
+		return false, nil
+	}
+	if r.err != nil {
+		return false, fmt.Errorf("nested validator at index %d: %v", i, r.err)
+	}
+	return true, nil
+}
+
+// ParallelAnd is a ga4gh.Validator that, like And, returns false if any of the
+// wrapped validators returns false, but invokes all of them concurrently.  Once
+// the outcome is known the context passed to the validators that are still
+// running is cancelled.  The result, including any error, is the same as that
+// of an And wrapping the same validators.
+type ParallelAnd []ga4gh.Validator
+
+// Validate returns false if any of the wrapped validators return false.  If a
+// validator returns an error before any validator with a lower index returns
+// false then an error is returned.
+func (and ParallelAnd) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	i, r, err := runParallel(ctx, identity, and, func(r result) bool { return !r.ok })
+	if err != nil {
+		return false, err
+	}
+	if i < 0 {
+		return true, nil
+	}
+	if r.err != nil {
+		return false, fmt.Errorf("nested validator at index %d: %v", i, r.err)
+	}
+	return false, nil
+}
+
+// result is the outcome of a single validator run by runParallel.
+type result struct {
+	index int
+	ok    bool
+	err   error
+}
+
+// runParallel invokes vs concurrently and considers their results in index
+// order, returning the first that has an error or for which decisive returns
+// true, or an index of -1 if there is none.  Considering results in index
+// order makes the outcome independent of the order in which the validators
+// finish.  It only returns an error itself if ctx is done first.
+func runParallel(ctx context.Context, identity *ga4gh.Identity, vs []ga4gh.Validator, decisive func(result) bool) (int, result, error) {
+	vctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The channel is buffered so that validators that finish after the
+	// outcome is known do not block.
+	results := make(chan result, len(vs))
+	for i, v := range vs {
+		go func(i int, v ga4gh.Validator) {
+			ok, err := v.Validate(vctx, identity)
+			results <- result{index: i, ok: ok, err: err}
+		}(i, v)
+	}
+
+	finished := make([]*result, len(vs))
+	next := 0
+	for next < len(vs) {
+		select {
+		case r := <-results:
+			finished[r.index] = &r
+		case <-ctx.Done():
+			return 0, result{}, ctx.Err()
+		}
+		for ; next < len(vs) && finished[next] != nil; next++ {
+			if r := *finished[next]; r.err != nil || decisive(r) {
+				return next, r, nil
+			}
+		}
+	}
+	return -1, result{}, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// delayed is a validator that returns the result of Validator after Delay.
+type delayed struct {
+	Delay     time.Duration
+	Validator ga4gh.Validator
+}
+
+func (d *delayed) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	time.Sleep(d.Delay)
+	return d.Validator.Validate(ctx, identity)
+}
+
+// blocking is a validator that waits for its context to be done and then
+// closes cancelled.
+type blocking struct {
+	cancelled chan struct{}
+}
+
+func (b *blocking) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	<-ctx.Done()
+	close(b.cancelled)
+	return false, ctx.Err()
+}
+
+// waiting is a validator that ignores its context and waits for release to be
+// closed.
+type waiting struct {
+	release chan struct{}
+}
+
+func (w *waiting) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	<-w.release
+	return true, nil
+}
+
+func TestParallelMatchesSequential(t *testing.T) {
+	var (
+		yes  = &Constant{OK: true}
+		no   = &Constant{OK: false}
+		fail = &Constant{Err: errors.New("failure")}
+		// slow results finish after the others, to check that results are
+		// considered in index order.
+		slowYes  = &delayed{Delay: 20 * time.Millisecond, Validator: yes}
+		slowNo   = &delayed{Delay: 20 * time.Millisecond, Validator: no}
+		slowFail = &delayed{Delay: 20 * time.Millisecond, Validator: fail}
+	)
+	tests := [][]ga4gh.Validator{
+		{},
+		{yes},
+		{no},
+		{yes, yes},
+		{yes, no},
+		{no, yes},
+		{no, no},
+		{fail, yes},
+		{yes, fail},
+		{no, fail},
+		{slowFail, yes},
+		{slowFail, no},
+		{slowYes, fail},
+		{slowNo, fail},
+		{slowNo, slowYes, no},
+	}
+	ctx := context.Background()
+	for _, vs := range tests {
+		t.Run(fmt.Sprintf("%v", vs), func(t *testing.T) {
+			pairs := []struct {
+				name                 string
+				sequential, parallel ga4gh.Validator
+			}{
+				{name: "or", sequential: Or(vs), parallel: ParallelOr(vs)},
+				{name: "and", sequential: And(vs), parallel: ParallelAnd(vs)},
+			}
+			for _, p := range pairs {
+				wantOK, wantErr := p.sequential.Validate(ctx, &ga4gh.Identity{})
+				gotOK, gotErr := p.parallel.Validate(ctx, &ga4gh.Identity{})
+				if gotOK != wantOK || fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+					t.Errorf("parallel %s = (%v, %v), want = (%v, %v)", p.name, gotOK, gotErr, wantOK, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelCancelsRemaining(t *testing.T) {
+	tests := []struct {
+		name string
+		in   func(*blocking) ga4gh.Validator
+		want bool
+	}{
+		{
+			name: "or",
+			in:   func(b *blocking) ga4gh.Validator { return ParallelOr{&Constant{OK: true}, b} },
+			want: true,
+		},
+		{
+			name: "and",
+			in:   func(b *blocking) ga4gh.Validator { return ParallelAnd{&Constant{OK: false}, b} },
+			want: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &blocking{cancelled: make(chan struct{})}
+			ok, err := test.in(b).Validate(context.Background(), &ga4gh.Identity{})
+			if ok != test.want || err != nil {
+				t.Fatalf("Validate() = (%v, %v), want = (%v, nil)", ok, err, test.want)
+			}
+			select {
+			case <-b.cancelled:
+			case <-time.After(5 * time.Second):
+				t.Fatal("remaining validator was not cancelled")
+			}
+		})
+	}
+}
+
+func TestParallelContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &waiting{release: make(chan struct{})}
+	defer close(w.release)
+	go cancel()
+	if ok, err := (ParallelAnd{w}).Validate(ctx, &ga4gh.Identity{}); ok || err != context.Canceled {
+		t.Fatalf("Validate() = (%v, %v), want = (false, %v)", ok, err, context.Canceled)
+	}
+}
@@ -29,11 +29,23 @@ var valueType = map[reflect.Type]bool{
 	reflect.TypeOf([]ga4gh.BoolValue{}):   true,
 }
 
+// isValueField returns true iff the named field of ga4gh.Identity has a
+// valueType, and so carries a source and timestamps with each value.
+func isValueField(name string) bool {
+	field, ok := reflect.TypeOf(ga4gh.Identity{}).FieldByName(name)
+	return ok && valueType[field.Type]
+}
+
+// AnyOf is a value for a Simple validator that matches if any of the values it
+// contains match.
+type AnyOf []interface{}
+
 // Simple is a ga4gh.Validator that compares the values in the incoming
 // identity to match the keys and values it contains.  For example, the Simple
 // validator: &Simple{"Role": "human"} would validate all identities containing
-// at least one Role with the value "human".  The Simple Validator does not
-// inspect the source of claims.
+// at least one Role with the value "human".  Values must have the same type
+// as the values of the corresponding field, or be an AnyOf of such values.
+// The Simple Validator does not inspect the source of claims; see Sourced.
 type Simple map[string]interface{}
 
 // Validate returns true iff there is a corresponding field in the input that
@@ -49,7 +61,7 @@ func (s Simple) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, e
 		if valueType[field.Type()] {
 			var matched bool
 			for i := 0; i < field.Len(); i++ {
-				if reflect.DeepEqual(field.Index(i).FieldByName("Value").Interface(), expected) {
+				if matches(field.Index(i).FieldByName("Value").Interface(), expected) {
 					matched = true
 				}
 			}
@@ -57,10 +69,37 @@ func (s Simple) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, e
 				return false, nil
 			}
 		} else {
-			if !reflect.DeepEqual(field.Interface(), expected) {
+			if !matches(field.Interface(), expected) {
 				return false, nil
 			}
 		}
 	}
 	return true, nil
 }
+
+func matches(actual, expected interface{}) bool {
+	if any, ok := expected.(AnyOf); ok {
+		for _, e := range any {
+			if matches(actual, e) {
+				return true
+			}
+		}
+		return false
+	}
+	return reflect.DeepEqual(actual, expected)
+}
+
+// FieldType returns the type of the values held by the named field of
+// ga4gh.Identity, which is the type that Simple compares expected values
+// against.  It returns false if there is no such field.
+func FieldType(name string) (reflect.Type, bool) {
+	field, ok := reflect.TypeOf(ga4gh.Identity{}).FieldByName(name)
+	if !ok {
+		return nil, false
+	}
+	if valueType[field.Type] {
+		value, _ := field.Type.Elem().FieldByName("Value")
+		return value.Type, true
+	}
+	return field.Type, true
+}
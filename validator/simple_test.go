@@ -50,6 +50,22 @@ func TestSimple(t *testing.T) {
 			validator: Simple{"Role": "researcher"},
 			ok:        false,
 		},
+		{
+			name: "any of",
+			id: &ga4gh.Identity{
+				Role: []ga4gh.StringValue{{Value: "curator"}},
+			},
+			validator: Simple{"Role": AnyOf{"researcher", "curator"}},
+			ok:        true,
+		},
+		{
+			name: "bool field",
+			id: &ga4gh.Identity{
+				BonaFide: []ga4gh.BoolValue{{Value: true}},
+			},
+			validator: Simple{"BonaFide": "true"},
+			ok:        false,
+		},
 		{
 			name:      "scalar field",
 			id:        &ga4gh.Identity{Issuer: "https://very-real.idp"},
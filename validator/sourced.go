@@ -0,0 +1,92 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// SourcedClaim is an expected claim value along with the sources that are
+// trusted to assert it.
+type SourcedClaim struct {
+	// Value is compared with claim values in the same way as the values of a
+	// Simple validator.
+	Value interface{}
+	// Sources lists the trusted sources.  A source ending in "*" matches any
+	// source with the preceding prefix, otherwise sources must match exactly.
+	Sources []string
+}
+
+// Sourced is a ga4gh.Validator that, like Simple, compares the claims in the
+// incoming identity with the values it contains, but only accepts claims that
+// were asserted by a trusted source.  For example, the Sourced validator:
+//
+//	Sourced{"BonaFide": {Value: true, Sources: []string{"https://login.elixir-czech.org/oidc/"}}}
+//
+// would validate all identities with a BonaFide claim of true asserted by
+// ELIXIR.  Only claims that carry a source may be used.
+type Sourced map[string]SourcedClaim
+
+// Validate returns true iff, for each of the claims in the Sourced, the
+// corresponding field in the input contains at least one value that matches
+// the claim and was asserted by one of its sources.
+func (s Sourced) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	v := reflect.ValueOf(*identity)
+	for name, claim := range s {
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return false, fmt.Errorf("no field named %q on ga4gh.Identity", name)
+		}
+		if !valueType[field.Type()] {
+			return false, fmt.Errorf("field %q of ga4gh.Identity has no source", name)
+		}
+		var matched bool
+		for i := 0; i < field.Len(); i++ {
+			elem := field.Index(i)
+			if matches(elem.FieldByName("Value").Interface(), claim.Value) && claim.trusts(elem.FieldByName("Source").String()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c *SourcedClaim) trusts(source string) bool {
+	for _, trusted := range c.Sources {
+		if strings.HasSuffix(trusted, "*") {
+			if strings.HasPrefix(source, strings.TrimSuffix(trusted, "*")) {
+				return true
+			}
+		} else if source == trusted {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSource returns true iff the named field of ga4gh.Identity holds values
+// that carry a source, and so can be used with a Sourced validator.
+func HasSource(name string) bool {
+	return isValueField(name)
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestSourced(t *testing.T) {
+	const (
+		elixir = "https://login.elixir-czech.org/oidc/"
+		nih    = "https://auth.nih.gov/"
+	)
+	bonaFide := Sourced{"BonaFide": {Value: true, Sources: []string{elixir, "https://auth.nih.gov/*"}}}
+	tests := []struct {
+		name      string
+		id        *ga4gh.Identity
+		validator Sourced
+		ok        bool
+		err       bool
+	}{
+		{
+			name:      "trusted source",
+			id:        &ga4gh.Identity{BonaFide: []ga4gh.BoolValue{{Value: true, Source: elixir}}},
+			validator: bonaFide,
+			ok:        true,
+		},
+		{
+			name:      "trusted source prefix",
+			id:        &ga4gh.Identity{BonaFide: []ga4gh.BoolValue{{Value: true, Source: nih + "dbgap"}}},
+			validator: bonaFide,
+			ok:        true,
+		},
+		{
+			name:      "untrusted source",
+			id:        &ga4gh.Identity{BonaFide: []ga4gh.BoolValue{{Value: true, Source: "https://evil.example.com/"}}},
+			validator: bonaFide,
+			ok:        false,
+		},
+		{
+			name: "value and source from different claims",
+			id: &ga4gh.Identity{BonaFide: []ga4gh.BoolValue{
+				{Value: false, Source: elixir},
+				{Value: true, Source: "https://evil.example.com/"},
+			}},
+			validator: bonaFide,
+			ok:        false,
+		},
+		{
+			name:      "field without source",
+			id:        &ga4gh.Identity{Issuer: elixir},
+			validator: Sourced{"Issuer": {Value: elixir, Sources: []string{elixir}}},
+			err:       true,
+		},
+	}
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := test.validator.Validate(ctx, test.id)
+			if test.err != (err != nil) {
+				t.Fatalf("Unexpected error during validation: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected validation result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// TimedClaim is an expected claim value along with constraints on when it was
+// asserted and when it expires.
+type TimedClaim struct {
+	// Value is compared with claim values in the same way as the values of a
+	// Simple validator.  If it is nil then any value is accepted.
+	Value interface{}
+	// MaxAge, if non-zero, is the longest time that may have passed since the
+	// claim was asserted.  Claims with an unknown assertion time are rejected.
+	MaxAge time.Duration
+	// MinRemaining, if non-zero, is the shortest time that must remain before
+	// the claim expires.  Claims with an unknown expiry time are rejected.
+	MinRemaining time.Duration
+}
+
+// Timed is a ga4gh.Validator that, like Simple, compares the claims in the
+// incoming identity with the values it contains, but only accepts claims that
+// were asserted recently enough and do not expire too soon.  Claims that have
+// already expired are never accepted.  For example, the Timed validator:
+//
+//	Timed{"BonaFide": {Value: true, MaxAge: 365 * 24 * time.Hour}}
+//
+// would validate all identities with a BonaFide claim of true asserted within
+// the last year.  The current time is taken from the context using ga4gh.Now.
+type Timed map[string]TimedClaim
+
+// Validate returns true iff, for each of the claims in the Timed, the
+// corresponding field in the input contains at least one value that matches
+// the claim and satisfies its time constraints.
+func (t Timed) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	now := ga4gh.Now(ctx)
+	v := reflect.ValueOf(*identity)
+	for name, claim := range t {
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return false, fmt.Errorf("no field named %q on ga4gh.Identity", name)
+		}
+		if !valueType[field.Type()] {
+			return false, fmt.Errorf("field %q of ga4gh.Identity has no timestamps", name)
+		}
+		var matched bool
+		for i := 0; i < field.Len(); i++ {
+			elem := field.Index(i)
+			if claim.Value != nil && !matches(elem.FieldByName("Value").Interface(), claim.Value) {
+				continue
+			}
+			if claim.current(now, elem.FieldByName("Asserted").Int(), elem.FieldByName("Expires").Int()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// current reports whether a claim with the given assertion and expiry times,
+// in seconds since the Unix epoch, satisfies the constraints of c at now.
+func (c *TimedClaim) current(now time.Time, asserted, expires int64) bool {
+	if expires != 0 && !now.Before(time.Unix(expires, 0)) {
+		return false
+	}
+	if c.MaxAge != 0 && (asserted == 0 || now.Sub(time.Unix(asserted, 0)) > c.MaxAge) {
+		return false
+	}
+	if c.MinRemaining != 0 && (expires == 0 || time.Unix(expires, 0).Sub(now) < c.MinRemaining) {
+		return false
+	}
+	return true
+}
+
+// HasTimestamps returns true iff the named field of ga4gh.Identity holds values
+// that carry assertion and expiry times, and so can be used with a Timed
+// validator.
+func HasTimestamps(name string) bool {
+	return isValueField(name)
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestTimed(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) int64 { return now.Add(-d).Unix() }
+	id := &ga4gh.Identity{
+		Role: []ga4gh.StringValue{
+			{Value: "researcher", Asserted: ago(400 * 24 * time.Hour)},
+			{Value: "curator", Asserted: ago(time.Hour), Expires: ago(-30 * time.Minute)},
+			{Value: "expired", Asserted: ago(time.Hour), Expires: ago(time.Minute)},
+		},
+		BonaFide: []ga4gh.BoolValue{
+			{Value: true, Asserted: ago(30 * 24 * time.Hour)},
+		},
+	}
+	const year = 365 * 24 * time.Hour
+	tests := []struct {
+		name      string
+		validator Timed
+		ok        bool
+		err       bool
+	}{
+		{
+			name:      "asserted recently",
+			validator: Timed{"BonaFide": {Value: true, MaxAge: year}},
+			ok:        true,
+		},
+		{
+			name:      "asserted too long ago",
+			validator: Timed{"Role": {Value: "researcher", MaxAge: year}},
+			ok:        false,
+		},
+		{
+			name:      "any value asserted recently",
+			validator: Timed{"Role": {MaxAge: 2 * time.Hour}},
+			ok:        true,
+		},
+		{
+			name:      "expires too soon",
+			validator: Timed{"Role": {Value: "curator", MinRemaining: time.Hour}},
+			ok:        false,
+		},
+		{
+			name:      "expires late enough",
+			validator: Timed{"Role": {Value: "curator", MinRemaining: 10 * time.Minute}},
+			ok:        true,
+		},
+		{
+			name:      "unknown expiry",
+			validator: Timed{"BonaFide": {Value: true, MinRemaining: time.Minute}},
+			ok:        false,
+		},
+		{
+			name:      "expired",
+			validator: Timed{"Role": {Value: "expired"}},
+			ok:        false,
+		},
+		{
+			name:      "no timestamps",
+			validator: Timed{"Issuer": {MaxAge: year}},
+			err:       true,
+		},
+	}
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := test.validator.Validate(ctx, id)
+			if test.err != (err != nil) {
+				t.Fatalf("Unexpected error during validation: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected validation result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+// Visa is a ga4gh.Validator that succeeds if the identity has an active visa
+// that meets its Type, Value, Source and By fields in the same way as a visa
+// condition, and satisfies its time constraints.  For example, the Visa
+// validator:
+//
+//	&Visa{Type: "ControlledAccessGrants", Value: "const:https://example.org/datasets/710"}
+//
+// would validate all identities that have been granted access to dataset 710.
+// Visas whose conditions are not met are ignored; see ga4gh.ActiveVisas.  The
+// current time is taken from the context using ga4gh.Now.
+type Visa struct {
+	Type   string
+	Value  string
+	Source string
+	By     string
+	// MaxAge, if non-zero, is the longest time that may have passed since the
+	// visa was asserted.  Visas with an unknown assertion time are rejected.
+	MaxAge time.Duration
+	// MinRemaining, if non-zero, is the shortest time that must remain before
+	// the visa expires.  Visas that do not expire are rejected.
+	MinRemaining time.Duration
+}
+
+// Validate implements the ga4gh.Validator interface.
+func (v *Visa) Validate(ctx context.Context, identity *ga4gh.Identity) (bool, error) {
+	c := &ga4gh.Condition{Type: v.Type, Value: v.Value, Source: v.Source, By: v.By}
+	constraints := &TimedClaim{MaxAge: v.MaxAge, MinRemaining: v.MinRemaining}
+	now := ga4gh.Now(ctx)
+	for _, visa := range ga4gh.ActiveVisas(ctx, identity.Visas) {
+		if c.Matches(&visa) && constraints.current(now, visa.Asserted, visa.Expires) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ga4gh "github.com/googlegenomics/ga4gh-identity"
+)
+
+func TestVisa(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	id := &ga4gh.Identity{
+		Visas: []ga4gh.Visa{
+			{Type: "ResearcherStatus", Value: "https://doi.org/10.1038/s41431-018-0219-y", Source: "https://elixir-europe.org"},
+			{
+				Type:       "ControlledAccessGrants",
+				Value:      "https://example.org/datasets/710",
+				Conditions: [][]ga4gh.Condition{{{Type: "AffiliationAndRole"}}},
+			},
+			{
+				Type:     "AcceptedTermsAndPolicies",
+				Value:    "https://example.org/terms",
+				Asserted: now.Add(-time.Hour).Unix(),
+				Expires:  now.Add(48 * time.Hour).Unix(),
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		validator *Visa
+		ok        bool
+	}{
+		{
+			name:      "matching visa",
+			validator: &Visa{Type: "ResearcherStatus", Source: "pattern:https://*elixir*"},
+			ok:        true,
+		},
+		{
+			name:      "wrong source",
+			validator: &Visa{Type: "ResearcherStatus", Source: "const:https://example.org"},
+			ok:        false,
+		},
+		{
+			name:      "inactive visa",
+			validator: &Visa{Type: "ControlledAccessGrants", Value: "const:https://example.org/datasets/710"},
+			ok:        false,
+		},
+		{
+			name:      "recently asserted",
+			validator: &Visa{Type: "AcceptedTermsAndPolicies", MaxAge: 2 * time.Hour},
+			ok:        true,
+		},
+		{
+			name:      "asserted too long ago",
+			validator: &Visa{Type: "AcceptedTermsAndPolicies", MaxAge: 30 * time.Minute},
+			ok:        false,
+		},
+		{
+			name:      "unknown assertion time",
+			validator: &Visa{Type: "ResearcherStatus", MaxAge: time.Hour},
+			ok:        false,
+		},
+		{
+			name:      "enough time remaining",
+			validator: &Visa{Type: "AcceptedTermsAndPolicies", MinRemaining: 24 * time.Hour},
+			ok:        true,
+		},
+		{
+			name:      "expires too soon",
+			validator: &Visa{Type: "AcceptedTermsAndPolicies", MinRemaining: 72 * time.Hour},
+			ok:        false,
+		},
+		{
+			name:      "does not expire",
+			validator: &Visa{Type: "ResearcherStatus", MinRemaining: time.Hour},
+			ok:        false,
+		},
+	}
+	ctx := ga4gh.NewClockContext(context.Background(), func() time.Time { return now })
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := test.validator.Validate(ctx, id)
+			if err != nil {
+				t.Fatalf("Unexpected error during validation: %v", err)
+			}
+			if test.ok != ok {
+				t.Fatalf("Unexpected validation result, got = %v, wanted = %v", ok, test.ok)
+			}
+		})
+	}
+}
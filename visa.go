@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Visa is a decoded GA4GH Passport visa.  Asserted and Expires are in seconds
+// since the Unix epoch; an Expires of zero means the visa does not expire.
+type Visa struct {
+	Type     string `json:"type"`
+	Asserted int64  `json:"asserted"`
+	Expires  int64  `json:"expires,omitempty"`
+	Value    string `json:"value"`
+	Source   string `json:"source"`
+	By       string `json:"by,omitempty"`
+	// Conditions are in disjunctive normal form: the visa is only in effect
+	// if all of the conditions of at least one of the clauses are met.
+	Conditions [][]Condition `json:"conditions,omitempty"`
+}
+
+// Condition is met by another visa of the same passport that has the given
+// type and matches each of the value, source and by fields that are set.
+// Those fields are of the form "const:<value>", which must match exactly,
+// "pattern:<pattern>", in which "*" matches any sequence of characters and "?"
+// any single character, or "split_pattern:<pattern>", which matches if any of
+// the ";" separated parts of the field match the pattern.
+type Condition struct {
+	Type   string `json:"type"`
+	Value  string `json:"value,omitempty"`
+	Source string `json:"source,omitempty"`
+	By     string `json:"by,omitempty"`
+}
+
+// Matches returns true iff v meets c.
+func (c *Condition) Matches(v *Visa) bool {
+	return c.Type == v.Type &&
+		matchConditionField(c.Value, v.Value) &&
+		matchConditionField(c.Source, v.Source) &&
+		matchConditionField(c.By, v.By)
+}
+
+// CheckConditionField returns an error if s is not empty or in one of the
+// formats accepted for the value, source and by fields of a Condition.
+func CheckConditionField(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, prefix := range []string{"const:", "pattern:", "split_pattern:"} {
+		if strings.HasPrefix(s, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q does not start with const:, pattern: or split_pattern:", s)
+}
+
+func matchConditionField(field, value string) bool {
+	switch {
+	case field == "":
+		return true
+	case strings.HasPrefix(field, "const:"):
+		return value == strings.TrimPrefix(field, "const:")
+	case strings.HasPrefix(field, "pattern:"):
+		return matchPattern(strings.TrimPrefix(field, "pattern:"), value)
+	case strings.HasPrefix(field, "split_pattern:"):
+		pattern := strings.TrimPrefix(field, "split_pattern:")
+		for _, part := range strings.Split(value, ";") {
+			if matchPattern(pattern, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPattern returns true iff s matches pattern, in which "*" matches any
+// sequence of characters and "?" any single character.
+func matchPattern(pattern, s string) bool {
+	p, v := []rune(pattern), []rune(s)
+	// star is the position of the most recent "*" in p and mark the position
+	// in v that it was last assumed to match up to, for backtracking.
+	i, j, star, mark := 0, 0, -1, 0
+	for j < len(v) {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == v[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, mark = i, j
+			i++
+		case star >= 0:
+			mark++
+			i, j = star+1, mark
+		default:
+			return false
+		}
+	}
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
+
+// ActiveVisas returns the visas that are in effect at the time given by
+// Now(ctx): those that have not expired and, if they have conditions, meet all
+// of the conditions of at least one clause using other active visas.  Visas
+// whose conditions depend on each other in a cycle are not active.
+func ActiveVisas(ctx context.Context, visas []Visa) []Visa {
+	now := Now(ctx).Unix()
+	active := make([]bool, len(visas))
+	for changed := true; changed; {
+		changed = false
+		for i := range visas {
+			if active[i] || (visas[i].Expires != 0 && now >= visas[i].Expires) {
+				continue
+			}
+			if conditionsMet(visas, active, i) {
+				active[i] = true
+				changed = true
+			}
+		}
+	}
+	var result []Visa
+	for i, v := range visas {
+		if active[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// conditionsMet returns true iff the conditions of visas[i] are met by the
+// other visas marked as active.
+func conditionsMet(visas []Visa, active []bool, i int) bool {
+	if len(visas[i].Conditions) == 0 {
+		return true
+	}
+	for _, clause := range visas[i].Conditions {
+		met := true
+		for _, c := range clause {
+			if !metByActive(&c, visas, active, i) {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true
+		}
+	}
+	return false
+}
+
+func metByActive(c *Condition, visas []Visa, active []bool, self int) bool {
+	for j := range visas {
+		if j != self && active[j] && c.Matches(&visas[j]) {
+			return true
+		}
+	}
+	return false
+}
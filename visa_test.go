@@ -0,0 +1,184 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ga4gh
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConditionMatches(t *testing.T) {
+	visa := &Visa{
+		Type:   "AffiliationAndRole",
+		Value:  "faculty@uni.example.org",
+		Source: "https://uni.example.org",
+		By:     "so",
+	}
+	tests := []struct {
+		name      string
+		condition Condition
+		want      bool
+	}{
+		{name: "type only", condition: Condition{Type: "AffiliationAndRole"}, want: true},
+		{name: "wrong type", condition: Condition{Type: "ResearcherStatus"}, want: false},
+		{name: "const", condition: Condition{Type: "AffiliationAndRole", Value: "const:faculty@uni.example.org"}, want: true},
+		{name: "const mismatch", condition: Condition{Type: "AffiliationAndRole", Value: "const:faculty"}, want: false},
+		{name: "pattern", condition: Condition{Type: "AffiliationAndRole", Value: "pattern:faculty@*.example.org"}, want: true},
+		{name: "pattern single", condition: Condition{Type: "AffiliationAndRole", By: "pattern:s?"}, want: true},
+		{name: "pattern mismatch", condition: Condition{Type: "AffiliationAndRole", Source: "pattern:*.example.com"}, want: false},
+		{name: "unknown format", condition: Condition{Type: "AffiliationAndRole", By: "so"}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.condition.Matches(visa); got != test.want {
+				t.Fatalf("Matches() = %v, want = %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{pattern: "", s: "", want: true},
+		{pattern: "*", s: "", want: true},
+		{pattern: "a*b*c", s: "aXXbYYbc", want: true},
+		{pattern: "a*b", s: "aXXbY", want: false},
+		{pattern: "?", s: "é", want: true},
+		{pattern: "a?c", s: "ac", want: false},
+		{pattern: "**x", s: "yyx", want: true},
+	}
+	for _, test := range tests {
+		if got := matchPattern(test.pattern, test.s); got != test.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want = %v", test.pattern, test.s, got, test.want)
+		}
+	}
+}
+
+func TestActiveVisas(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := NewClockContext(context.Background(), func() time.Time { return now })
+	affiliation := Visa{Type: "AffiliationAndRole", Value: "faculty@uni.example.org", Source: "https://uni.example.org"}
+	expired := Visa{Type: "AcceptedTermsAndPolicies", Value: "https://example.org/terms", Expires: now.Unix()}
+	grant := func(value string, conditions ...[]Condition) Visa {
+		return Visa{Type: "ControlledAccessGrants", Value: value, Conditions: conditions}
+	}
+	requireAffiliation := []Condition{{Type: "AffiliationAndRole", Value: "pattern:faculty@*"}}
+	requireTerms := []Condition{{Type: "AcceptedTermsAndPolicies"}}
+	requireGrant := func(value string) []Condition {
+		return []Condition{{Type: "ControlledAccessGrants", Value: "const:" + value}}
+	}
+
+	tests := []struct {
+		name  string
+		visas []Visa
+		want  []Visa
+	}{
+		{
+			name:  "no conditions",
+			visas: []Visa{affiliation},
+			want:  []Visa{affiliation},
+		},
+		{
+			name:  "expired",
+			visas: []Visa{affiliation, expired},
+			want:  []Visa{affiliation},
+		},
+		{
+			name:  "condition met",
+			visas: []Visa{grant("a", requireAffiliation), affiliation},
+			want:  []Visa{grant("a", requireAffiliation), affiliation},
+		},
+		{
+			name:  "condition unmet",
+			visas: []Visa{grant("a", requireAffiliation)},
+		},
+		{
+			name:  "condition met only by expired visa",
+			visas: []Visa{grant("a", requireTerms), expired},
+		},
+		{
+			name:  "second clause met",
+			visas: []Visa{grant("a", requireTerms, requireAffiliation), affiliation},
+			want:  []Visa{grant("a", requireTerms, requireAffiliation), affiliation},
+		},
+		{
+			name:  "clause partially met",
+			visas: []Visa{grant("a", append(requireAffiliation, requireTerms...)), affiliation},
+			want:  []Visa{affiliation},
+		},
+		{
+			name:  "chain",
+			visas: []Visa{grant("b", requireGrant("a")), grant("a", requireAffiliation), affiliation},
+			want:  []Visa{grant("b", requireGrant("a")), grant("a", requireAffiliation), affiliation},
+		},
+		{
+			name:  "cycle",
+			visas: []Visa{grant("a", requireGrant("b")), grant("b", requireGrant("a"))},
+		},
+		{
+			name:  "condition on itself",
+			visas: []Visa{grant("a", requireGrant("a"))},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ActiveVisas(ctx, test.visas); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("ActiveVisas() = %+v, want = %+v", got, test.want)
+			}
+		})
+	}
+}
+
+type staticShim struct {
+	identity *Identity
+}
+
+func (s *staticShim) Shim(ctx context.Context, auth string) (*Identity, error) {
+	return s.identity, nil
+}
+
+type visaValidator struct{}
+
+func (visaValidator) Validate(ctx context.Context, identity *Identity) (bool, error) {
+	return len(identity.Visas) > 0, nil
+}
+
+func TestEvaluatorRemovesInactiveVisas(t *testing.T) {
+	ctx := context.Background()
+	id := &Identity{
+		Visas: []Visa{{
+			Type:       "ControlledAccessGrants",
+			Value:      "a",
+			Conditions: [][]Condition{{{Type: "AffiliationAndRole"}}},
+		}},
+	}
+	parser, err := NewParser(ctx, []Shim{&staticShim{identity: id}}, nil)
+	if err != nil {
+		t.Fatalf("NewParser() = %v", err)
+	}
+	ev := &Evaluator{Parser: parser, Validator: visaValidator{}}
+	if _, err := ev.Evaluate(ctx, "token"); err == nil {
+		t.Fatal("Evaluate() succeeded with only an inactive visa, want error")
+	}
+	if len(id.Visas) != 1 {
+		t.Fatalf("Evaluate() modified the parsed identity: %+v", id.Visas)
+	}
+}